@@ -20,11 +20,14 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	hashiversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/observiq/bindplane-op-enterprise/client"
 	"github.com/observiq/bindplane-op-enterprise/model"
@@ -34,6 +37,27 @@ import (
 // BindPlane client interface
 type BindPlane struct {
 	Client client.Bindplane
+
+	// LastWriterWins disables CheckVersion, allowing Terraform
+	// to overwrite changes made outside of Terraform.
+	LastWriterWins bool
+
+	// applied tracks the components created or updated by
+	// this client, see CheckVersion.
+	applied   map[model.Kind]map[string]struct{}
+	appliedMu sync.Mutex
+
+	// serverVersion caches the server's version, or the error
+	// returned when getting it, see ServerVersion.
+	serverVersion    *hashiversion.Version
+	serverVersionErr error
+	serverVersionMu  sync.Mutex
+
+	// selectors caches the agent selectors of the configurations
+	// in Bindplane, see ConfigurationSelectors.
+	selectors    map[string]map[string]string
+	selectorsErr error
+	selectorsMu  sync.Mutex
 }
 
 // Apply creates or updates a single BindPlane resource and returns it's id.
@@ -56,6 +80,9 @@ func (i *BindPlane) Apply(r *model.AnyResource, rollout bool) error {
 		switch status.Status {
 		case model.StatusUnchanged:
 		case model.StatusConfigured, model.StatusCreated:
+			if status.Resource.Kind != model.KindConfiguration {
+				i.markApplied(status.Resource.Kind, resource.Name())
+			}
 			if rollout && status.Resource.Kind == model.KindConfiguration {
 				if err := i.Rollout(resource.Name()); err != nil {
 					errs = errors.Join(errs, err)
@@ -145,6 +172,15 @@ func (i *BindPlane) Configuration(name string) (*model.Configuration, error) {
 	return c, nil
 }
 
+// Configurations returns all configurations
+func (i *BindPlane) Configurations() ([]*model.Configuration, error) {
+	c, err := i.Client.Configurations(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configurations: %w", err)
+	}
+	return c, nil
+}
+
 // DeleteConfiguration will delete a BindPlane configuration
 func (i *BindPlane) DeleteConfiguration(name string) error {
 	err := i.Client.DeleteConfiguration(context.Background(), name)
@@ -217,6 +253,15 @@ func (i *BindPlane) Processor(name string) (*model.Processor, error) {
 	return r, nil
 }
 
+// Processors returns all processors, including processor bundles
+func (i *BindPlane) Processors() ([]*model.Processor, error) {
+	p, err := i.Client.Processors(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processors: %w", err)
+	}
+	return p, nil
+}
+
 // DeleteProcessor will delete a BindPlane processor
 func (i *BindPlane) DeleteProcessor(name string) error {
 	err := i.Client.DeleteProcessor(context.Background(), name)
@@ -266,17 +311,56 @@ func (i *BindPlane) Delete(k model.Kind, name string) error {
 	case model.KindConnector:
 		return i.DeleteConnector(name)
 	default:
-		return fmt.Errorf("Delete does not support bindplane kind '%s'", k)
+		return i.DeleteAnyResource(k, name)
 	}
 }
 
+// DeleteAnyResource will delete a Bindplane resource of any kind
+func (i *BindPlane) DeleteAnyResource(k model.Kind, name string) error {
+	err := i.Client.DeleteResource(context.Background(), k, name)
+	if err != nil {
+		return fmt.Errorf("error while deleting %s with name %s: %w", k, name, err)
+	}
+	return nil
+}
+
+// DeleteWithRetry wraps Delete with the ability to retry on retryable errors.
+// Deletes rejected because the resource still has dependent resources are
+// retried as well, giving in flight configuration updates time to remove
+// their reference to the resource. If the dependent resources remain when
+// the timeout is reached, a *DependentResourcesError is returned.
+func (i *BindPlane) DeleteWithRetry(ctx context.Context, timeout time.Duration, k model.Kind, name string) error {
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		if err := i.Delete(k, name); err != nil {
+			if retryableError(err) || isDependentResourcesError(err) {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		if dependents := parseDependentResources(err); dependents != nil {
+			return &DependentResourcesError{
+				Kind:       k,
+				Name:       name,
+				Dependents: dependents,
+				Err:        err,
+			}
+		}
+		return fmt.Errorf("bindplane delete retries exhausted: %w", err)
+	}
+	return nil
+}
+
 // GenericResource represents a Bindplane resource's
-// id, name, version, and ParameterizedSpec.
+// id, name, version, metadata, and ParameterizedSpec.
 type GenericResource struct {
-	ID      string
-	Name    string
-	Version model.Version
-	Spec    model.ParameterizedSpec
+	ID       string
+	Name     string
+	Version  model.Version
+	Metadata model.Metadata
+	Spec     model.ParameterizedSpec
 }
 
 // GenericResource looks up a Bindplane resource and returns a GenericResource.
@@ -299,6 +383,7 @@ func (i *BindPlane) GenericResource(k model.Kind, name string) (*GenericResource
 		g.ID = r.ID()
 		g.Name = r.Name()
 		g.Version = r.Version()
+		g.Metadata = r.Metadata
 		g.Spec = r.Spec
 	case model.KindSource:
 		r, err := i.Source(name)
@@ -313,6 +398,7 @@ func (i *BindPlane) GenericResource(k model.Kind, name string) (*GenericResource
 		g.ID = r.ID()
 		g.Name = r.Name()
 		g.Version = r.Version()
+		g.Metadata = r.Metadata
 		g.Spec = r.Spec
 	case model.KindProcessor:
 		r, err := i.Processor(name)
@@ -327,6 +413,7 @@ func (i *BindPlane) GenericResource(k model.Kind, name string) (*GenericResource
 		g.ID = r.ID()
 		g.Name = r.Name()
 		g.Version = r.Version()
+		g.Metadata = r.Metadata
 		g.Spec = r.Spec
 	case model.KindExtension:
 		r, err := i.Extension(name)
@@ -341,6 +428,7 @@ func (i *BindPlane) GenericResource(k model.Kind, name string) (*GenericResource
 		g.ID = r.ID()
 		g.Name = r.Name()
 		g.Version = r.Version()
+		g.Metadata = r.Metadata
 		g.Spec = r.Spec
 	case model.KindConnector:
 		r, err := i.Connector(name)
@@ -355,14 +443,63 @@ func (i *BindPlane) GenericResource(k model.Kind, name string) (*GenericResource
 		g.ID = r.ID()
 		g.Name = r.Name()
 		g.Version = r.Version()
+		g.Metadata = r.Metadata
 		g.Spec = r.Spec
 	default:
-		return nil, fmt.Errorf("GenericResource does not support bindplane kind '%s'", k)
+		// Kinds without a typed client method are read without their
+		// spec, which is not always a ParameterizedSpec.
+		r, err := i.AnyResource(k, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if r == nil {
+			return nil, nil
+		}
+
+		g.ID = r.ID()
+		g.Name = r.Name()
+		g.Version = r.Version()
+		g.Metadata = r.Metadata
 	}
 
 	return g, nil
 }
 
+// AnyResource looks up a Bindplane resource of any kind and returns it
+// as a model.AnyResource. The returned resource will be nil if it does
+// not exist. It is up to the caller to check.
+func (i *BindPlane) AnyResource(k model.Kind, name string) (*model.AnyResource, error) {
+	r, err := i.Client.Resource(context.Background(), k, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s with name %s: %w", k, name, err)
+	}
+
+	if r == nil {
+		return nil, nil
+	}
+
+	return toAnyResource(r)
+}
+
+// toAnyResource converts a typed Bindplane resource to a model.AnyResource
+// by way of its json representation.
+func toAnyResource(r model.Resource) (*model.AnyResource, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s '%s': %w", r.GetKind(), r.Name(), err)
+	}
+
+	a := &model.AnyResource{}
+	if err := json.Unmarshal(b, a); err != nil {
+		return nil, fmt.Errorf("unmarshal %s '%s': %w", r.GetKind(), r.Name(), err)
+	}
+	return a, nil
+}
+
 // TODO(jsirianni): Bindplane should probably have error types so we can check
 // error.Is.
 func isNotFoundError(err error) bool {
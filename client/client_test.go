@@ -15,6 +15,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -60,7 +61,7 @@ func newTestConfig(endpoint, user, pass, ca, crt, key string) (*BindPlane, error
 		return nil, err
 	}
 
-	return &BindPlane{i}, nil
+	return &BindPlane{Client: i}, nil
 }
 
 func TestNewTestConfig(t *testing.T) {
@@ -142,3 +143,49 @@ func TestIsNotFoundError(t *testing.T) {
 		require.Equal(t, tc.expect, out)
 	}
 }
+
+func TestToAnyResource(t *testing.T) {
+	r := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: "bindplane.observiq.com/v1",
+			Kind:       model.Kind("Role"),
+			Metadata: model.Metadata{
+				ID:      "01HZ",
+				Name:    "viewer",
+				Version: 2,
+			},
+		},
+		Spec: map[string]any{
+			"permissions": []any{"read"},
+		},
+	}
+
+	a, err := toAnyResource(r)
+	require.NoError(t, err)
+	require.Equal(t, r, a)
+}
+
+// resourceClient is a Bindplane client which
+// returns err when getting a resource.
+type resourceClient struct {
+	client.Bindplane
+	err error
+}
+
+func (c resourceClient) Resource(context.Context, model.Kind, string) (model.Resource, error) {
+	return nil, c.err
+}
+
+func TestGenericResourceUnknownKind(t *testing.T) {
+	// Kinds without a typed client method are read with the generic
+	// resource API. A kind the server does not know is not found.
+	i := &BindPlane{Client: resourceClient{err: errors.New("404 Not Found")}}
+	g, err := i.GenericResource(model.Kind("NotAKind"), "agent")
+	require.NoError(t, err)
+	require.Nil(t, g)
+
+	// Other errors are returned
+	i = &BindPlane{Client: resourceClient{err: errors.New("400 Bad Request: unknown kind NotAKind")}}
+	_, err = i.GenericResource(model.Kind("NotAKind"), "agent")
+	require.EqualError(t, err, "failed to get NotAKind with name agent: 400 Bad Request: unknown kind NotAKind")
+}
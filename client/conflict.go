@@ -0,0 +1,125 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/dependents"
+)
+
+// VersionConflictError is returned when a resource was modified
+// in Bindplane after Terraform last read it.
+type VersionConflictError struct {
+	Kind model.Kind
+	Name string
+
+	// Expected is the version Terraform last read
+	Expected model.Version
+
+	// Current is the version Bindplane currently has
+	Current model.Version
+}
+
+// Error returns a message describing the conflict and how to resolve it
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf(
+		"resource changed since plan: %s '%s' is at version %d in Bindplane but Terraform last read version %d. "+
+			"It was likely modified outside of Terraform. Run terraform plan again to review the changes, "+
+			"or set the provider option last_writer_wins to overwrite them",
+		e.Kind, e.Name, e.Current, e.Expected)
+}
+
+// CheckVersion returns a *VersionConflictError if the resource's version in
+// Bindplane differs from the expected version. The check is skipped when
+// LastWriterWins is enabled, when the expected version is zero (the resource
+// has not been read yet), or when the resource does not exist.
+func (i *BindPlane) CheckVersion(k model.Kind, name string, expected model.Version) error {
+	if i.LastWriterWins || expected == 0 {
+		return nil
+	}
+
+	var current model.Version
+	switch k {
+	case model.KindConfiguration:
+		c, err := i.Configuration(name)
+		if err != nil {
+			return fmt.Errorf("check version: %w", err)
+		}
+		if c == nil {
+			return nil
+		}
+
+		// Bindplane creates a new configuration version when a component
+		// referenced by the configuration is updated. Components updated
+		// earlier in the same Terraform run are not a conflict.
+		if i.referencesApplied(c) {
+			return nil
+		}
+		current = c.Version()
+	default:
+		g, err := i.GenericResource(k, name)
+		if err != nil {
+			return fmt.Errorf("check version: %w", err)
+		}
+		if g == nil {
+			return nil
+		}
+		current = g.Version
+	}
+
+	if current == expected {
+		return nil
+	}
+
+	return &VersionConflictError{
+		Kind:     k,
+		Name:     name,
+		Expected: expected,
+		Current:  current,
+	}
+}
+
+// markApplied records that a component was created or updated
+// by this client.
+func (i *BindPlane) markApplied(k model.Kind, name string) {
+	i.appliedMu.Lock()
+	defer i.appliedMu.Unlock()
+
+	if i.applied == nil {
+		i.applied = map[model.Kind]map[string]struct{}{}
+	}
+	if i.applied[k] == nil {
+		i.applied[k] = map[string]struct{}{}
+	}
+	i.applied[k][name] = struct{}{}
+}
+
+// referencesApplied returns true if the configuration references
+// a component which was created or updated by this client.
+func (i *BindPlane) referencesApplied(c *model.Configuration) bool {
+	i.appliedMu.Lock()
+	defer i.appliedMu.Unlock()
+
+	for k, names := range i.applied {
+		for name := range names {
+			if len(dependents.InConfiguration(c, k, name)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionConflictError(t *testing.T) {
+	err := &VersionConflictError{
+		Kind:     model.KindConfiguration,
+		Name:     "my-config",
+		Expected: 3,
+		Current:  4,
+	}
+	require.Contains(t, err.Error(), "resource changed since plan")
+	require.Contains(t, err.Error(), "Configuration 'my-config' is at version 4 in Bindplane but Terraform last read version 3")
+}
+
+func TestCheckVersionSkipped(t *testing.T) {
+	// Neither case should call Bindplane, which is not configured
+	// and would otherwise panic.
+	i := &BindPlane{}
+	require.NoError(t, i.CheckVersion(model.KindConfiguration, "my-config", 0))
+
+	i.LastWriterWins = true
+	require.NoError(t, i.CheckVersion(model.KindConfiguration, "my-config", 3))
+}
+
+// Bindplane is not configured, API calls should fail
+func TestCheckVersion(t *testing.T) {
+	i, err := newTestConfig("", "", "", "", "", "")
+	require.NoError(t, err)
+	require.Error(t, i.CheckVersion(model.KindConfiguration, "my-config", 3))
+}
+
+func TestReferencesApplied(t *testing.T) {
+	c := &model.Configuration{
+		Spec: model.ConfigurationSpec{
+			Destinations: []model.ResourceConfiguration{
+				{Name: "otlp:2"},
+			},
+		},
+	}
+
+	i := &BindPlane{}
+	require.False(t, i.referencesApplied(c))
+
+	i.markApplied(model.KindDestination, "loki")
+	require.False(t, i.referencesApplied(c))
+
+	i.markApplied(model.KindDestination, "otlp")
+	require.True(t, i.referencesApplied(c))
+}
@@ -0,0 +1,91 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// dependentResourcesPrefix is the prefix Bindplane uses when refusing to
+// delete a resource that is still referenced by other resources. It is
+// followed by one "<Kind> <name>" line per dependent resource.
+const dependentResourcesPrefix = "Dependent resources:"
+
+// Dependent is a resource which references another resource.
+type Dependent struct {
+	Kind model.Kind
+	Name string
+}
+
+// DependentResourcesError is returned when Bindplane refuses to delete
+// a resource because it is still referenced by other resources.
+type DependentResourcesError struct {
+	// Kind and Name identify the resource that could not be deleted
+	Kind model.Kind
+	Name string
+
+	// Dependents are the resources still referencing the resource
+	Dependents []Dependent
+
+	// Err is the error returned by Bindplane
+	Err error
+}
+
+// Error returns the underlying Bindplane error message
+func (e *DependentResourcesError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying Bindplane error
+func (e *DependentResourcesError) Unwrap() error {
+	return e.Err
+}
+
+// isDependentResourcesError returns true if Bindplane refused
+// an operation because of dependent resources.
+func isDependentResourcesError(err error) bool {
+	return strings.Contains(err.Error(), dependentResourcesPrefix)
+}
+
+// parseDependentResources returns the dependent resources listed in a
+// "Dependent resources" error. Returns nil if the error does not list
+// any dependents.
+func parseDependentResources(err error) []Dependent {
+	e := err.Error()
+
+	i := strings.Index(e, dependentResourcesPrefix)
+	if i < 0 {
+		return nil
+	}
+
+	dependents := []Dependent{}
+	for _, line := range strings.Split(e[i+len(dependentResourcesPrefix):], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		dependents = append(dependents, Dependent{
+			Kind: model.Kind(fields[0]),
+			Name: fields[1],
+		})
+	}
+
+	if len(dependents) == 0 {
+		return nil
+	}
+	return dependents
+}
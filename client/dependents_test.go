@@ -0,0 +1,92 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDependentResourcesError(t *testing.T) {
+	require.True(t, isDependentResourcesError(errors.New("Dependent resources:\nConfiguration my-config")))
+	require.False(t, isDependentResourcesError(errors.New("404 Not Found")))
+	require.False(t, isDependentResourcesError(errors.New("")))
+}
+
+func TestParseDependentResources(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		expect []Dependent
+	}{
+		{
+			"single",
+			errors.New("error while deleting destination with name example-custom: Dependent resources:\nConfiguration my-config"),
+			[]Dependent{
+				{Kind: model.KindConfiguration, Name: "my-config"},
+			},
+		},
+		{
+			"multiple",
+			errors.New("Dependent resources:\nConfiguration my-config\nProcessor my-bundle\n"),
+			[]Dependent{
+				{Kind: model.KindConfiguration, Name: "my-config"},
+				{Kind: model.KindProcessor, Name: "my-bundle"},
+			},
+		},
+		{
+			"wrapped",
+			fmt.Errorf("bindplane delete retries exhausted: %w", errors.New("Dependent resources:\n  Configuration my-config  ")),
+			[]Dependent{
+				{Kind: model.KindConfiguration, Name: "my-config"},
+			},
+		},
+		{
+			"no dependents listed",
+			errors.New("Dependent resources:"),
+			nil,
+		},
+		{
+			"other error",
+			errors.New("404 Not Found"),
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, parseDependentResources(tc.err))
+		})
+	}
+}
+
+func TestDependentResourcesError(t *testing.T) {
+	inner := errors.New("Dependent resources:\nConfiguration my-config")
+	err := fmt.Errorf("delete: %w", &DependentResourcesError{
+		Kind: model.KindDestination,
+		Name: "otlp",
+		Err:  inner,
+	})
+
+	var depErr *DependentResourcesError
+	require.True(t, errors.As(err, &depErr))
+	require.Equal(t, "otlp", depErr.Name)
+	require.ErrorIs(t, err, inner)
+	require.Equal(t, inner.Error(), depErr.Error())
+}
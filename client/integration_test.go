@@ -433,10 +433,11 @@ func TestIntegration_http_config(t *testing.T) {
 	require.NoError(t, err)
 
 	err = i.Delete(model.KindAgent, "agent")
-	require.Error(t, err, "Generic delete does not support agent")
+	require.Error(t, err, "expected an error when deleting an agent that does not exist")
 
-	_, err = i.GenericResource(model.KindAgent, "agent")
-	require.Error(t, err, "Generic get does not support agent")
+	g, err := i.GenericResource(model.KindAgent, "agent")
+	require.NoError(t, err, "Generic get does not return an error for an agent that does not exist")
+	require.Nil(t, g)
 
 	extensionsResource := model.AnyResource{
 		ResourceMeta: model.ResourceMeta{
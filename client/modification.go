@@ -0,0 +1,95 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// Modification describes the most recent change to a resource.
+type Modification struct {
+	Version model.Version
+
+	// UpdatedBy is the user who made the change. Empty
+	// if Bindplane does not report it.
+	UpdatedBy string
+
+	// UpdatedAt is the time of the change. Empty if
+	// Bindplane does not report it.
+	UpdatedAt string
+}
+
+// LastModification returns the most recent change to a resource.
+// Returns nil if the resource does not exist.
+func (i *BindPlane) LastModification(k model.Kind, name string) (*Modification, error) {
+	var (
+		version  model.Version
+		metadata model.Metadata
+	)
+
+	switch k {
+	case model.KindConfiguration:
+		c, err := i.Configuration(name)
+		if err != nil || c == nil {
+			return nil, err
+		}
+		version = c.Version()
+		metadata = c.Metadata
+	default:
+		g, err := i.GenericResource(k, name)
+		if err != nil || g == nil {
+			return nil, err
+		}
+		version = g.Version
+		metadata = g.Metadata
+	}
+
+	m, err := modificationFromMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("read %s '%s' metadata: %w", k, name, err)
+	}
+	m.Version = version
+	return m, nil
+}
+
+// modificationFromMetadata reads the modification fields from resource
+// metadata. The fields are read from the metadata's json representation
+// because the fields reported vary between Bindplane versions.
+func modificationFromMetadata(metadata model.Metadata) (*Modification, error) {
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	m := &Modification{}
+	if v, ok := fields["updatedBy"].(string); ok {
+		m.UpdatedBy = v
+	}
+	for _, key := range []string{"updatedAt", "dateModified"} {
+		if v, ok := fields[key].(string); ok && v != "" {
+			m.UpdatedAt = v
+			break
+		}
+	}
+	return m, nil
+}
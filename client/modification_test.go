@@ -0,0 +1,47 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModificationFromMetadata(t *testing.T) {
+	modified := time.Date(2025, 2, 7, 14, 50, 54, 0, time.UTC)
+
+	m, err := modificationFromMetadata(model.Metadata{
+		Name:         "my-config",
+		DateModified: &modified,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2025-02-07T14:50:54Z", m.UpdatedAt)
+
+	m, err = modificationFromMetadata(model.Metadata{Name: "my-config"})
+	require.NoError(t, err)
+	require.Equal(t, &Modification{}, m)
+}
+
+// Bindplane is not configured, API calls should fail
+func TestLastModification(t *testing.T) {
+	i, err := newTestConfig("", "", "", "", "", "")
+	require.NoError(t, err)
+
+	_, err = i.LastModification(model.KindConfiguration, "my-config")
+	require.Error(t, err)
+}
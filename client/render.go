@@ -0,0 +1,33 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// RenderedConfiguration returns the OpenTelemetry collector configuration
+// Bindplane renders for the named configuration. When agentID is set, the
+// configuration is rendered as it would be sent to that agent. Otherwise,
+// it is rendered for the platform, which defaults to the configuration's
+// platform when empty.
+func (i *BindPlane) RenderedConfiguration(name, agentID, platform string) (string, error) {
+	raw, err := i.Client.RenderConfiguration(context.Background(), name, agentID, platform)
+	if err != nil {
+		return "", fmt.Errorf("failed to render configuration with name %s: %w", name, err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,90 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sort"
+)
+
+// ConfigurationSelectors returns the agent selector match labels of the
+// configurations in Bindplane, by configuration name. The configurations
+// are listed once and cached for the lifetime of the client, so every
+// configuration planned by Terraform is compared against the same
+// selectors, regardless of the order they are planned in.
+func (i *BindPlane) ConfigurationSelectors() (map[string]map[string]string, error) {
+	i.selectorsMu.Lock()
+	defer i.selectorsMu.Unlock()
+
+	if i.selectors != nil || i.selectorsErr != nil {
+		return i.selectors, i.selectorsErr
+	}
+
+	configs, err := i.Configurations()
+	if err != nil {
+		i.selectorsErr = err
+		return nil, err
+	}
+
+	selectors := map[string]map[string]string{}
+	for _, c := range configs {
+		matchLabels := map[string]string{}
+		for k, v := range c.Spec.Selector.MatchLabels {
+			matchLabels[k] = v
+		}
+		selectors[c.Name()] = matchLabels
+	}
+	i.selectors = selectors
+	return selectors, nil
+}
+
+// OverlappingSelectors returns the names of the configurations in selectors,
+// other than name, whose selectors overlap with matchLabels, sorted by name.
+func OverlappingSelectors(name string, matchLabels map[string]string, selectors map[string]map[string]string) []string {
+	overlapping := []string{}
+	for other, otherLabels := range selectors {
+		if other == name {
+			continue
+		}
+		if SelectorsOverlap(matchLabels, otherLabels) {
+			overlapping = append(overlapping, other)
+		}
+	}
+	sort.Strings(overlapping)
+	return overlapping
+}
+
+// SelectorsOverlap returns true if every agent matched by one selector is
+// matched by the other, which is the case when the labels of one selector
+// are a subset of the labels of the other. These configurations compete
+// for the same agents. Selectors which do not share a label, such as a
+// custom selector and a default configuration=<name> selector, only match
+// agents which have been labeled for both, and do not overlap. Empty
+// selectors match no agents.
+func SelectorsOverlap(a, b map[string]string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	return labelsSubset(a, b) || labelsSubset(b, a)
+}
+
+// labelsSubset returns true if every label in a is in b
+func labelsSubset(a, b map[string]string) bool {
+	for k, v := range a {
+		if other, ok := b[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/client"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorsOverlap(t *testing.T) {
+	cases := []struct {
+		name   string
+		a      map[string]string
+		b      map[string]string
+		expect bool
+	}{
+		{"identical", map[string]string{"env": "prod"}, map[string]string{"env": "prod"}, true},
+		{"subset", map[string]string{"env": "prod"}, map[string]string{"env": "prod", "role": "web"}, true},
+		{"superset", map[string]string{"env": "prod", "role": "web"}, map[string]string{"env": "prod"}, true},
+		{"different values", map[string]string{"env": "prod"}, map[string]string{"env": "dev"}, false},
+		{"disjoint keys", map[string]string{"role": "web"}, map[string]string{"env": "prod"}, false},
+		{"custom and default selectors", map[string]string{"env": "prod"}, map[string]string{"configuration": "web"}, false},
+		{"partially shared keys", map[string]string{"env": "prod", "role": "web"}, map[string]string{"env": "prod", "tier": "db"}, false},
+		{"shared key with different values", map[string]string{"env": "prod", "role": "web"}, map[string]string{"env": "dev", "role": "web"}, false},
+		{"default selectors", map[string]string{"configuration": "a"}, map[string]string{"configuration": "b"}, false},
+		{"empty", map[string]string{}, map[string]string{"env": "prod"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, SelectorsOverlap(tc.a, tc.b))
+		})
+	}
+}
+
+// configurationsClient is a Bindplane client which lists
+// configurations, and counts how often they are listed.
+type configurationsClient struct {
+	client.Bindplane
+	configs []*model.Configuration
+	err     error
+	calls   int
+}
+
+func (c *configurationsClient) Configurations(context.Context) ([]*model.Configuration, error) {
+	c.calls++
+	return c.configs, c.err
+}
+
+func testConfiguration(name string, matchLabels map[string]string) *model.Configuration {
+	c := &model.Configuration{}
+	c.Metadata.Name = name
+	c.Spec.Selector.MatchLabels = matchLabels
+	return c
+}
+
+func TestConfigurationSelectors(t *testing.T) {
+	c := &configurationsClient{
+		configs: []*model.Configuration{
+			testConfiguration("prod", map[string]string{"env": "prod"}),
+			testConfiguration("web", map[string]string{"configuration": "web"}),
+		},
+	}
+	i := &BindPlane{Client: c}
+
+	expect := map[string]map[string]string{
+		"prod": {"env": "prod"},
+		"web":  {"configuration": "web"},
+	}
+	selectors, err := i.ConfigurationSelectors()
+	require.NoError(t, err)
+	require.Equal(t, expect, selectors)
+
+	// Configurations are listed once
+	selectors, err = i.ConfigurationSelectors()
+	require.NoError(t, err)
+	require.Equal(t, expect, selectors)
+	require.Equal(t, 1, c.calls)
+
+	// Errors are cached as well
+	c = &configurationsClient{err: errors.New("connection refused")}
+	i = &BindPlane{Client: c}
+	_, err = i.ConfigurationSelectors()
+	require.EqualError(t, err, "failed to list configurations: connection refused")
+	_, err = i.ConfigurationSelectors()
+	require.Error(t, err)
+	require.Equal(t, 1, c.calls)
+}
+
+func TestOverlappingSelectors(t *testing.T) {
+	selectors := map[string]map[string]string{
+		"prod":     {"env": "prod"},
+		"dev":      {"env": "dev"},
+		"prod-web": {"env": "prod", "role": "web"},
+		"web":      {"configuration": "web"},
+	}
+
+	require.Equal(t, []string{"prod-web"}, OverlappingSelectors("prod", map[string]string{"env": "prod"}, selectors))
+	require.Equal(t, []string{"prod", "prod-web"}, OverlappingSelectors("prod-all", map[string]string{"env": "prod"}, selectors))
+	require.Empty(t, OverlappingSelectors("staging", map[string]string{"env": "staging"}, selectors))
+
+	// A custom selector does not overlap with default selectors
+	require.Empty(t, OverlappingSelectors("frontend", map[string]string{"role": "frontend"}, selectors))
+}
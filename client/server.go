@@ -0,0 +1,57 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	hashiversion "github.com/hashicorp/go-version"
+)
+
+// ServerVersion returns the version of the Bindplane server. The
+// version is fetched once and cached for the lifetime of the client.
+// A failed lookup is cached as well, so that an unreachable or old
+// server is not queried again for every resource.
+func (i *BindPlane) ServerVersion() (*hashiversion.Version, error) {
+	i.serverVersionMu.Lock()
+	defer i.serverVersionMu.Unlock()
+
+	if i.serverVersion != nil || i.serverVersionErr != nil {
+		return i.serverVersion, i.serverVersionErr
+	}
+
+	i.serverVersion, i.serverVersionErr = i.fetchServerVersion()
+	return i.serverVersion, i.serverVersionErr
+}
+
+// fetchServerVersion gets and parses the version of the Bindplane server
+func (i *BindPlane) fetchServerVersion() (*hashiversion.Version, error) {
+	v, err := i.Client.Version(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bindplane server version: %w", err)
+	}
+	return parseServerVersion(v.Version)
+}
+
+// parseServerVersion parses a Bindplane version string such
+// as "v1.85.0".
+func parseServerVersion(v string) (*hashiversion.Version, error) {
+	version, err := hashiversion.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bindplane server version '%s': %w", v, err)
+	}
+	return version, nil
+}
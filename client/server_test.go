@@ -0,0 +1,103 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/client"
+	"github.com/observiq/bindplane-op-enterprise/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	cases := []struct {
+		input     string
+		expect    string
+		expectErr bool
+	}{
+		{"v1.85.0", "1.85.0", false},
+		{"1.92.1", "1.92.1", false},
+		{"v1.90.2-rc.1", "1.90.2-rc.1", false},
+		{"latest", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := parseServerVersion(tc.input)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, v.String())
+		})
+	}
+}
+
+// Bindplane is not configured, API calls should fail
+func TestServerVersion(t *testing.T) {
+	i, err := newTestConfig("", "", "", "", "", "")
+	require.NoError(t, err)
+
+	_, err = i.ServerVersion()
+	require.Error(t, err)
+}
+
+// versionClient is a Bindplane client which returns a version,
+// and counts how often it is called.
+type versionClient struct {
+	client.Bindplane
+	version string
+	err     error
+	calls   int
+}
+
+func (c *versionClient) Version(context.Context) (version.Version, error) {
+	c.calls++
+	return version.Version{Version: c.version}, c.err
+}
+
+func TestServerVersionCached(t *testing.T) {
+	c := &versionClient{version: "v1.92.1"}
+	i := &BindPlane{Client: c}
+	for range 2 {
+		v, err := i.ServerVersion()
+		require.NoError(t, err)
+		require.Equal(t, "1.92.1", v.String())
+	}
+	require.Equal(t, 1, c.calls)
+
+	// Failures are cached as well
+	c = &versionClient{err: errors.New("connection refused")}
+	i = &BindPlane{Client: c}
+	for range 2 {
+		_, err := i.ServerVersion()
+		require.EqualError(t, err, "failed to get bindplane server version: connection refused")
+	}
+	require.Equal(t, 1, c.calls)
+
+	// Versions which cannot be parsed are unknown
+	c = &versionClient{version: "latest"}
+	i = &BindPlane{Client: c}
+	for range 2 {
+		_, err := i.ServerVersion()
+		require.Error(t, err)
+	}
+	require.Equal(t, 1, c.calls)
+}
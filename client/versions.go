@@ -0,0 +1,171 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// RollbackLabel is set on the configuration version created by a rollback.
+// Its value is the historical version which was restored.
+const RollbackLabel = "terraform-rollback-version"
+
+// rolloutStatusNames are the names of Bindplane's numeric rollout statuses
+var rolloutStatusNames = map[model.RolloutStatus]string{
+	model.RolloutStatusPending:  "pending",
+	model.RolloutStatusStarted:  "started",
+	model.RolloutStatusPaused:   "paused",
+	model.RolloutStatusError:    "error",
+	model.RolloutStatusStable:   "stable",
+	model.RolloutStatusReplaced: "replaced",
+}
+
+// ConfigurationVersion describes a single version of a configuration.
+type ConfigurationVersion struct {
+	Modification
+
+	// RolloutStatus is the status of the version's rollout, such as
+	// "started" or "stable". Empty if the version was never rolled out.
+	RolloutStatus string
+
+	// Current is true if the version is rolled out to agents
+	Current bool
+
+	// Pending is true if the version is being rolled out to agents
+	Pending bool
+
+	// Latest is true if the version is the most recent version
+	Latest bool
+
+	// Resource is the configuration as of this version
+	Resource *model.AnyResource
+}
+
+// ConfigurationVersions returns the version history of a configuration,
+// newest first. Returns nil if the configuration does not exist.
+func (i *BindPlane) ConfigurationVersions(name string) ([]*ConfigurationVersion, error) {
+	history, err := i.Client.ResourceHistory(context.Background(), model.KindConfiguration, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get history of configuration with name %s: %w", name, err)
+	}
+
+	versions := make([]*ConfigurationVersion, 0, len(history))
+	for _, r := range history {
+		if r == nil {
+			continue
+		}
+		v, err := configurationVersion(r)
+		if err != nil {
+			return nil, fmt.Errorf("read configuration '%s' version %d: %w", name, r.Metadata.Version, err)
+		}
+		versions = append(versions, v)
+	}
+
+	sort.Slice(versions, func(a, b int) bool {
+		return versions[a].Version > versions[b].Version
+	})
+	return versions, nil
+}
+
+// ConfigurationVersion returns a single version of a configuration.
+// Returns nil if the configuration or version does not exist.
+func (i *BindPlane) ConfigurationVersion(name string, version model.Version) (*ConfigurationVersion, error) {
+	versions, err := i.ConfigurationVersions(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+// configurationVersion reads a configuration version from a historical
+// configuration. The status is read from the resource's json representation
+// because the status fields reported vary between Bindplane versions.
+func configurationVersion(r *model.AnyResource) (*ConfigurationVersion, error) {
+	m, err := modificationFromMetadata(r.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	m.Version = r.Metadata.Version
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := struct {
+		Status map[string]any `json:"status"`
+	}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	v := &ConfigurationVersion{
+		Modification: *m,
+		Resource:     r,
+	}
+	v.Current, _ = fields.Status["current"].(bool)
+	v.Pending, _ = fields.Status["pending"].(bool)
+	v.Latest, _ = fields.Status["latest"].(bool)
+
+	if rollout, ok := fields.Status["rollout"].(map[string]any); ok {
+		v.RolloutStatus = rolloutStatusName(rollout["status"])
+	}
+	return v, nil
+}
+
+// rolloutStatusName returns the name of a rollout status, which is
+// reported as a number or a string depending on the Bindplane version.
+func rolloutStatusName(status any) string {
+	switch s := status.(type) {
+	case string:
+		return s
+	case float64:
+		if name, ok := rolloutStatusNames[model.RolloutStatus(s)]; ok {
+			return name
+		}
+		return fmt.Sprintf("%d", int(s))
+	default:
+		return ""
+	}
+}
+
+// RollbackVersion returns the historical version restored by the rollback
+// which created the configuration's latest version. Returns zero if the
+// latest version was not created by a rollback.
+func RollbackVersion(c *model.Configuration) model.Version {
+	v, ok := c.Metadata.Labels.AsMap()[RollbackLabel]
+	if !ok {
+		return 0
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return model.Version(version)
+}
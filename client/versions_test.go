@@ -0,0 +1,93 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutStatusName(t *testing.T) {
+	cases := []struct {
+		status any
+		expect string
+	}{
+		{float64(1), "started"},
+		{float64(5), "stable"},
+		{float64(42), "42"},
+		{"paused", "paused"},
+		{nil, ""},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.expect, rolloutStatusName(tc.status))
+	}
+}
+
+func TestRollbackVersion(t *testing.T) {
+	cases := []struct {
+		name   string
+		labels map[string]string
+		expect model.Version
+	}{
+		{"no-labels", nil, 0},
+		{"not-rolled-back", map[string]string{"platform": "linux"}, 0},
+		{"rolled-back", map[string]string{"platform": "linux", RollbackLabel: "3"}, 3},
+		{"invalid", map[string]string{RollbackLabel: "three"}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			labels, err := model.LabelsFromMap(tc.labels)
+			require.NoError(t, err)
+
+			c := &model.Configuration{}
+			c.Metadata.Labels = labels
+			require.Equal(t, tc.expect, RollbackVersion(c))
+		})
+	}
+}
+
+func TestConfigurationVersion(t *testing.T) {
+	modified := time.Date(2025, 2, 7, 14, 50, 54, 0, time.UTC)
+	r := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			Kind: model.KindConfiguration,
+			Metadata: model.Metadata{
+				Name:         "my-config",
+				Version:      3,
+				DateModified: &modified,
+			},
+		},
+	}
+
+	v, err := configurationVersion(r)
+	require.NoError(t, err)
+	require.Equal(t, model.Version(3), v.Version)
+	require.Equal(t, "2025-02-07T14:50:54Z", v.UpdatedAt)
+	require.Equal(t, r, v.Resource)
+}
+
+// Bindplane is not configured, API calls should fail
+func TestConfigurationVersions(t *testing.T) {
+	i, err := newTestConfig("", "", "", "", "", "")
+	require.NoError(t, err)
+
+	_, err = i.ConfigurationVersions("my-config")
+	require.Error(t, err)
+}
@@ -0,0 +1,248 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// RouteNode is a configuration block which routes telemetry,
+// is routed to, or both.
+type RouteNode struct {
+	// Block identifies the configuration block in errors,
+	// such as "source.0" or "destination.1".
+	Block string
+
+	// Prefix is the route component prefix used to route to the
+	// node, such as RoutePrefixDestination. Empty for sources,
+	// which cannot be routed to.
+	Prefix string
+
+	// RouteID is the ID used to route to the node. Empty for sources.
+	RouteID string
+
+	// Routes are the node's routes to other nodes
+	Routes *model.Routes
+
+	// TelemetryTypes are the telemetry types supported by the node's
+	// component type, such as "logs". Nil if not known, in which case
+	// the node's routes are not checked for compatibility.
+	TelemetryTypes []string
+}
+
+// supports returns true if the node supports the telemetry type,
+// or its telemetry types are not known.
+func (n RouteNode) supports(telemetryType string) bool {
+	if n.TelemetryTypes == nil {
+		return true
+	}
+	for _, t := range n.TelemetryTypes {
+		if strings.EqualFold(t, telemetryType) {
+			return true
+		}
+	}
+	return false
+}
+
+// path returns the route component path of the node, such as
+// "destinations/my-destination".
+func (n RouteNode) path() string {
+	return fmt.Sprintf("%s/%s", n.Prefix, n.RouteID)
+}
+
+// routeEdge is a route from one node to another
+type routeEdge struct {
+	to      int
+	routeID string
+}
+
+// ValidateRouteGraph validates the routes between a configuration's nodes.
+// It returns an error for each duplicate route ID, route to a component
+// which does not exist, destination which cannot be reached from a source,
+// and cycle. Reachability is only checked when the configuration defines
+// at least one route.
+//
+// Routes are also checked against the telemetry types of the nodes. A
+// source's routes must route a telemetry type the source supports, and
+// destinations and connectors must support the telemetry types routed
+// to them. Connectors can emit a different telemetry type than they
+// receive, so their routes are not checked.
+func ValidateRouteGraph(nodes []RouteNode) []error {
+	var errs []error
+
+	index := map[string]int{}
+	duplicate := make([]bool, len(nodes))
+	for i, n := range nodes {
+		if n.Prefix == "" {
+			continue
+		}
+		if j, ok := index[n.path()]; ok {
+			errs = append(errs, fmt.Errorf("%s: route_id '%s' is already used by %s", n.Block, n.RouteID, nodes[j].Block))
+			duplicate[i] = true
+			continue
+		}
+		index[n.path()] = i
+	}
+
+	edges := make([][]routeEdge, len(nodes))
+	hasRoutes := false
+	for i, n := range nodes {
+		for _, tr := range allRoutes(n.Routes) {
+			hasRoutes = true
+			route := tr.route
+
+			if n.Prefix == "" && !n.supports(tr.telemetryType) {
+				errs = append(errs, fmt.Errorf("%s: route '%s' routes %s, but the source only supports %s", n.Block, route.ID, tr.telemetryType, strings.Join(n.TelemetryTypes, ", ")))
+			}
+
+			for _, c := range route.Components {
+				if err := ValidateRouteComponents([]model.ComponentPath{c}); err != nil {
+					errs = append(errs, fmt.Errorf("%s: route '%s': invalid route component: %s", n.Block, route.ID, c))
+					continue
+				}
+				j, ok := index[string(c)]
+				if !ok {
+					errs = append(errs, fmt.Errorf("%s: route '%s' references %s which does not exist in the configuration", n.Block, route.ID, c))
+					continue
+				}
+				if target := nodes[j]; !target.supports(tr.telemetryType) {
+					errs = append(errs, fmt.Errorf("%s: route '%s' routes %s to %s, which only supports %s", n.Block, route.ID, tr.telemetryType, c, strings.Join(target.TelemetryTypes, ", ")))
+				}
+				edges[i] = append(edges[i], routeEdge{to: j, routeID: route.ID})
+			}
+		}
+	}
+
+	if hasRoutes {
+		errs = append(errs, unreachableDestinations(nodes, edges, duplicate)...)
+	}
+	errs = append(errs, routeCycles(nodes, edges)...)
+
+	return errs
+}
+
+// typedRoute is a route and the telemetry type it routes
+type typedRoute struct {
+	telemetryType string
+	route         model.Route
+}
+
+// allRoutes returns the logs, metrics, and traces routes
+func allRoutes(routes *model.Routes) []typedRoute {
+	if routes == nil {
+		return nil
+	}
+	all := []typedRoute{}
+	for _, r := range routes.Logs {
+		all = append(all, typedRoute{RouteTypeLogs, r})
+	}
+	for _, r := range routes.Metrics {
+		all = append(all, typedRoute{RouteTypeMetrics, r})
+	}
+	for _, r := range routes.Traces {
+		all = append(all, typedRoute{RouteTypeTraces, r})
+	}
+	return all
+}
+
+// unreachableDestinations returns an error for each destination which
+// cannot be reached from any source. Duplicate nodes are not reported,
+// routes to their route ID reach the first node with the ID.
+func unreachableDestinations(nodes []RouteNode, edges [][]routeEdge, duplicate []bool) []error {
+	visited := make([]bool, len(nodes))
+	queue := []int{}
+	for i, n := range nodes {
+		if n.Prefix == "" {
+			visited[i] = true
+			queue = append(queue, i)
+		}
+	}
+
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, e := range edges[i] {
+			if !visited[e.to] {
+				visited[e.to] = true
+				queue = append(queue, e.to)
+			}
+		}
+	}
+
+	var errs []error
+	for i, n := range nodes {
+		if n.Prefix == RoutePrefixDestination && !visited[i] && !duplicate[i] {
+			errs = append(errs, fmt.Errorf("%s: destination '%s' is not reachable from any source", n.Block, n.RouteID))
+		}
+	}
+	return errs
+}
+
+// routeCycles returns an error for each cycle in the route graph,
+// such as a connector which routes back to itself through a
+// processor group.
+func routeCycles(nodes []RouteNode, edges [][]routeEdge) []error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	var errs []error
+	state := make([]int, len(nodes))
+	stack := []int{}
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		stack = append(stack, i)
+
+		for _, e := range edges[i] {
+			switch state[e.to] {
+			case unvisited:
+				visit(e.to)
+			case visiting:
+				// Report the cycle from the first node on the stack
+				// which is part of it.
+				start := 0
+				for k, s := range stack {
+					if s == e.to {
+						start = k
+						break
+					}
+				}
+				blocks := []string{}
+				for _, s := range stack[start:] {
+					blocks = append(blocks, nodes[s].Block)
+				}
+				blocks = append(blocks, nodes[e.to].Block)
+				errs = append(errs, fmt.Errorf("%s: route '%s' creates a cycle: %s", nodes[i].Block, e.routeID, strings.Join(blocks, " -> ")))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[i] = done
+	}
+
+	for i := range nodes {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,159 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRouteGraph(t *testing.T) {
+	logs := func(id string, components ...string) *model.Routes {
+		paths := []model.ComponentPath{}
+		for _, c := range components {
+			paths = append(paths, model.ComponentPath(c))
+		}
+		return &model.Routes{Logs: []model.Route{{ID: id, Components: paths}}}
+	}
+
+	source := func(i int, routes *model.Routes) RouteNode {
+		return RouteNode{Block: fmt.Sprintf("source.%d", i), Routes: routes}
+	}
+	destination := func(block, id string) RouteNode {
+		return RouteNode{Block: block, Prefix: RoutePrefixDestination, RouteID: id}
+	}
+
+	cases := []struct {
+		name      string
+		nodes     []RouteNode
+		expectErr []string
+	}{
+		{
+			"valid",
+			[]RouteNode{
+				source(0, logs("a", "processors/parse")),
+				{Block: "processor_group.0", Prefix: RoutePrefixProcessor, RouteID: "parse", Routes: logs("b", "connectors/count", "destinations/google")},
+				{Block: "connector.0", Prefix: RoutePrefixConnector, RouteID: "count", Routes: logs("c", "destinations/google")},
+				destination("destination.0", "google"),
+			},
+			nil,
+		},
+		{
+			"no-routes",
+			[]RouteNode{
+				source(0, nil),
+				destination("destination.0", "google"),
+			},
+			nil,
+		},
+		{
+			"dangling",
+			[]RouteNode{
+				source(0, logs("a", "destinations/typo", "processors/missing")),
+				destination("destination.0", "google"),
+			},
+			[]string{
+				"source.0: route 'a' references destinations/typo which does not exist in the configuration",
+				"source.0: route 'a' references processors/missing which does not exist in the configuration",
+				"destination.0: destination 'google' is not reachable from any source",
+			},
+		},
+		{
+			"invalid-prefix",
+			[]RouteNode{
+				source(0, logs("a", "sources/host", "destinations/google")),
+				destination("destination.0", "google"),
+			},
+			[]string{
+				"source.0: route 'a': invalid route component: sources/host",
+			},
+		},
+		{
+			"duplicate",
+			[]RouteNode{
+				source(0, logs("a", "destinations/google")),
+				destination("destination.0", "google"),
+				destination("destination.1", "google"),
+			},
+			[]string{
+				"destination.1: route_id 'google' is already used by destination.0",
+			},
+		},
+		{
+			"unreachable",
+			[]RouteNode{
+				source(0, logs("a", "destinations/google")),
+				destination("destination.0", "google"),
+				destination("destination.1", "datadog"),
+			},
+			[]string{
+				"destination.1: destination 'datadog' is not reachable from any source",
+			},
+		},
+		{
+			"cycle",
+			[]RouteNode{
+				source(0, logs("a", "connectors/count")),
+				{Block: "connector.0", Prefix: RoutePrefixConnector, RouteID: "count", Routes: logs("b", "processors/parse", "destinations/google")},
+				{Block: "processor_group.0", Prefix: RoutePrefixProcessor, RouteID: "parse", Routes: logs("c", "connectors/count")},
+				destination("destination.0", "google"),
+			},
+			[]string{
+				"processor_group.0: route 'c' creates a cycle: connector.0 -> processor_group.0 -> connector.0",
+			},
+		},
+		{
+			"compatible-telemetry-types",
+			[]RouteNode{
+				{Block: "source.0", Routes: logs("a", "connectors/count"), TelemetryTypes: []string{"Logs", "Metrics"}},
+				{Block: "connector.0", Prefix: RoutePrefixConnector, RouteID: "count", TelemetryTypes: []string{"Logs"}, Routes: &model.Routes{
+					Metrics: []model.Route{{ID: "b", Components: []model.ComponentPath{"destinations/google"}}},
+				}},
+				{Block: "destination.0", Prefix: RoutePrefixDestination, RouteID: "google", TelemetryTypes: []string{"Metrics"}},
+			},
+			nil,
+		},
+		{
+			"incompatible-telemetry-types",
+			[]RouteNode{
+				{Block: "source.0", Routes: logs("a", "processors/parse"), TelemetryTypes: []string{"Metrics"}},
+				{Block: "processor_group.0", Prefix: RoutePrefixProcessor, RouteID: "parse", Routes: logs("b", "destinations/google")},
+				{Block: "destination.0", Prefix: RoutePrefixDestination, RouteID: "google", TelemetryTypes: []string{"Metrics", "Traces"}},
+			},
+			[]string{
+				"source.0: route 'a' routes logs, but the source only supports Metrics",
+				"processor_group.0: route 'b' routes logs to destinations/google, which only supports Metrics, Traces",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateRouteGraph(tc.nodes)
+			messages := []string{}
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			if tc.expectErr == nil {
+				require.Empty(t, messages)
+				return
+			}
+			require.Equal(t, tc.expectErr, messages)
+		})
+	}
+}
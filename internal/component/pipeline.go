@@ -0,0 +1,245 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// PipelineRouteIDPrefix is the prefix of the route IDs
+// generated for pipelines.
+const PipelineRouteIDPrefix = "pipeline"
+
+// Pipeline routes telemetry from sources, through an optional
+// list of processors, to destinations.
+type Pipeline struct {
+	// TelemetryTypes are the telemetry types to route, such as "logs"
+	TelemetryTypes []string
+
+	// Sources are the names of the sources to route from
+	Sources []string
+
+	// Processors are the names of the processors to route through,
+	// in order.
+	Processors []string
+
+	// Destinations are the names of the destinations to route to
+	Destinations []string
+}
+
+// PipelineComponents are the configuration components which
+// implement one or more pipelines.
+type PipelineComponents struct {
+	Sources      []model.ResourceConfiguration
+	Processors   []model.ResourceConfiguration
+	Destinations []model.ResourceConfiguration
+}
+
+// ExpandPipelines returns the sources, processor groups, and destinations
+// which implement the pipelines. A source or destination used by more than
+// one pipeline is only added once, sources combine the routes of each
+// pipeline. Processors are added as a processor group with the route ID
+// "pipeline-<index>" and destinations have the route ID "pipeline-<name>".
+func ExpandPipelines(pipelines []Pipeline) (PipelineComponents, error) {
+	c := PipelineComponents{
+		Sources:      []model.ResourceConfiguration{},
+		Processors:   []model.ResourceConfiguration{},
+		Destinations: []model.ResourceConfiguration{},
+	}
+
+	sources := map[string]int{}
+	destinations := map[string]bool{}
+
+	for i, p := range pipelines {
+		seen := map[string]bool{}
+		for _, t := range p.TelemetryTypes {
+			if err := ValidateRouteType(t); err != nil {
+				return c, fmt.Errorf("pipeline.%d: %w", i, err)
+			}
+			if seen[t] {
+				return c, fmt.Errorf("pipeline.%d: telemetry type %s is configured more than once", i, t)
+			}
+			seen[t] = true
+		}
+
+		destinationPaths := []model.ComponentPath{}
+		for _, name := range p.Destinations {
+			id := pipelineDestinationID(name)
+			destinationPaths = append(destinationPaths, model.ComponentPath(fmt.Sprintf("%s/%s", RoutePrefixDestination, id)))
+			if destinations[name] {
+				continue
+			}
+			destinations[name] = true
+			c.Destinations = append(c.Destinations, model.ResourceConfiguration{
+				ID:   id,
+				Name: name,
+			})
+		}
+
+		// Sources route to the processor group when the pipeline
+		// has processors, otherwise directly to the destinations.
+		sourcePaths := destinationPaths
+		if len(p.Processors) > 0 {
+			id := pipelineProcessorGroupID(i)
+			processors := []model.ResourceConfiguration{}
+			for _, name := range p.Processors {
+				processors = append(processors, model.ResourceConfiguration{Name: name})
+			}
+			c.Processors = append(c.Processors, model.ResourceConfiguration{
+				ID:                id,
+				ParameterizedSpec: model.ParameterizedSpec{Processors: processors},
+				Routes:            pipelineRoutes(i, p.TelemetryTypes, destinationPaths, nil),
+			})
+			sourcePaths = []model.ComponentPath{model.ComponentPath(fmt.Sprintf("%s/%s", RoutePrefixProcessor, id))}
+		}
+
+		for _, name := range p.Sources {
+			j, ok := sources[name]
+			if !ok {
+				j = len(c.Sources)
+				sources[name] = j
+				c.Sources = append(c.Sources, model.ResourceConfiguration{
+					Name:   name,
+					Routes: &model.Routes{},
+				})
+			}
+			c.Sources[j].Routes = pipelineRoutes(i, p.TelemetryTypes, sourcePaths, c.Sources[j].Routes)
+		}
+	}
+
+	return c, nil
+}
+
+// CollapsePipelines removes the components which implement the pipelines
+// from c, and returns the remaining components. Returns c and false if any
+// component of the pipelines is missing or does not match, such as when the
+// configuration was modified outside of Terraform.
+func CollapsePipelines(pipelines []Pipeline, c PipelineComponents) (PipelineComponents, bool) {
+	expected, err := ExpandPipelines(pipelines)
+	if err != nil {
+		return c, false
+	}
+
+	remaining := PipelineComponents{}
+	var ok bool
+	if remaining.Sources, ok = removeMatching(c.Sources, expected.Sources); !ok {
+		return c, false
+	}
+	if remaining.Processors, ok = removeMatching(c.Processors, expected.Processors); !ok {
+		return c, false
+	}
+	if remaining.Destinations, ok = removeMatching(c.Destinations, expected.Destinations); !ok {
+		return c, false
+	}
+	return remaining, true
+}
+
+// pipelineProcessorGroupID returns the route ID of a
+// pipeline's processor group.
+func pipelineProcessorGroupID(index int) string {
+	return fmt.Sprintf("%s-%d", PipelineRouteIDPrefix, index)
+}
+
+// pipelineDestinationID returns the route ID of a destination
+// used by a pipeline.
+func pipelineDestinationID(name string) string {
+	return fmt.Sprintf("%s-%s", PipelineRouteIDPrefix, name)
+}
+
+// pipelineRoutes appends a route to components for each telemetry type
+// to routes. Returns new routes when routes is nil.
+func pipelineRoutes(index int, telemetryTypes []string, components []model.ComponentPath, routes *model.Routes) *model.Routes {
+	if routes == nil {
+		routes = &model.Routes{}
+	}
+	for _, t := range telemetryTypes {
+		route := model.Route{
+			ID:         fmt.Sprintf("%s-%d-%s", PipelineRouteIDPrefix, index, t),
+			Components: components,
+		}
+		switch t {
+		case RouteTypeLogs:
+			routes.Logs = append(routes.Logs, route)
+		case RouteTypeMetrics:
+			routes.Metrics = append(routes.Metrics, route)
+		case RouteTypeTraces:
+			routes.Traces = append(routes.Traces, route)
+		}
+	}
+	return routes
+}
+
+// removeMatching removes one component matching each expected component
+// from components. Returns false if an expected component is not found.
+func removeMatching(components, expected []model.ResourceConfiguration) ([]model.ResourceConfiguration, bool) {
+	remaining := append([]model.ResourceConfiguration{}, components...)
+	for _, e := range expected {
+		found := false
+		for i, c := range remaining {
+			if pipelineComponentEqual(c, e) {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return components, false
+		}
+	}
+	return remaining, true
+}
+
+// pipelineComponentEqual returns true if c matches the expected pipeline
+// component. Resource versions and the order of routes are ignored.
+func pipelineComponentEqual(c, expected model.ResourceConfiguration) bool {
+	if c.ID != expected.ID && expected.ID != "" {
+		return false
+	}
+	if unversioned(c.Name) != expected.Name || c.Type != "" || len(c.Parameters) > 0 {
+		return false
+	}
+	if len(c.Processors) != len(expected.Processors) {
+		return false
+	}
+	for i, p := range c.Processors {
+		if p.Type != "" || unversioned(p.Name) != expected.Processors[i].Name {
+			return false
+		}
+	}
+	return reflect.DeepEqual(sortedRoutes(c.Routes), sortedRoutes(expected.Routes))
+}
+
+// sortedRoutes returns the routes sorted by ID. Nil and empty
+// routes are both returned as nil.
+func sortedRoutes(routes *model.Routes) []typedRoute {
+	all := allRoutes(routes)
+	if len(all) == 0 {
+		return nil
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].route.ID < all[j].route.ID
+	})
+	return all
+}
+
+// unversioned returns the resource name without its version
+func unversioned(name string) string {
+	return strings.Split(name, ":")[0]
+}
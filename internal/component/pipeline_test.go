@@ -0,0 +1,180 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPipelines(t *testing.T) {
+	pipelines := []Pipeline{
+		{
+			TelemetryTypes: []string{"logs", "metrics"},
+			Sources:        []string{"host"},
+			Processors:     []string{"batch"},
+			Destinations:   []string{"google", "otlp"},
+		},
+		{
+			TelemetryTypes: []string{"logs"},
+			Sources:        []string{"host", "files"},
+			Destinations:   []string{"google"},
+		},
+	}
+
+	c, err := ExpandPipelines(pipelines)
+	require.NoError(t, err)
+
+	toDestinations := []model.ComponentPath{"destinations/pipeline-google", "destinations/pipeline-otlp"}
+	require.Equal(t, []model.ResourceConfiguration{
+		{
+			ID: "pipeline-0",
+			ParameterizedSpec: model.ParameterizedSpec{
+				Processors: []model.ResourceConfiguration{{Name: "batch"}},
+			},
+			Routes: &model.Routes{
+				Logs:    []model.Route{{ID: "pipeline-0-logs", Components: toDestinations}},
+				Metrics: []model.Route{{ID: "pipeline-0-metrics", Components: toDestinations}},
+			},
+		},
+	}, c.Processors)
+
+	require.Equal(t, []model.ResourceConfiguration{
+		{ID: "pipeline-google", Name: "google"},
+		{ID: "pipeline-otlp", Name: "otlp"},
+	}, c.Destinations)
+
+	require.Equal(t, []model.ResourceConfiguration{
+		{
+			Name: "host",
+			Routes: &model.Routes{
+				Logs: []model.Route{
+					{ID: "pipeline-0-logs", Components: []model.ComponentPath{"processors/pipeline-0"}},
+					{ID: "pipeline-1-logs", Components: []model.ComponentPath{"destinations/pipeline-google"}},
+				},
+				Metrics: []model.Route{
+					{ID: "pipeline-0-metrics", Components: []model.ComponentPath{"processors/pipeline-0"}},
+				},
+			},
+		},
+		{
+			Name: "files",
+			Routes: &model.Routes{
+				Logs: []model.Route{
+					{ID: "pipeline-1-logs", Components: []model.ComponentPath{"destinations/pipeline-google"}},
+				},
+			},
+		},
+	}, c.Sources)
+
+	nodes := []RouteNode{}
+	for _, s := range c.Sources {
+		nodes = append(nodes, RouteNode{Block: s.Name, Routes: s.Routes})
+	}
+	for _, p := range c.Processors {
+		nodes = append(nodes, RouteNode{Block: p.ID, Prefix: RoutePrefixProcessor, RouteID: p.ID, Routes: p.Routes})
+	}
+	for _, d := range c.Destinations {
+		nodes = append(nodes, RouteNode{Block: d.ID, Prefix: RoutePrefixDestination, RouteID: d.ID})
+	}
+	require.Empty(t, ValidateRouteGraph(nodes))
+}
+
+func TestExpandPipelinesInvalid(t *testing.T) {
+	_, err := ExpandPipelines([]Pipeline{
+		{TelemetryTypes: []string{"logs"}, Sources: []string{"host"}, Destinations: []string{"google"}},
+		{TelemetryTypes: []string{"logs", "logs"}, Sources: []string{"host"}, Destinations: []string{"google"}},
+	})
+	require.EqualError(t, err, "pipeline.1: telemetry type logs is configured more than once")
+
+	_, err = ExpandPipelines([]Pipeline{
+		{TelemetryTypes: []string{"events"}, Sources: []string{"host"}, Destinations: []string{"google"}},
+	})
+	require.EqualError(t, err, "pipeline.0: invalid route type: events")
+}
+
+func TestCollapsePipelines(t *testing.T) {
+	pipelines := []Pipeline{
+		{
+			TelemetryTypes: []string{"logs", "traces"},
+			Sources:        []string{"host"},
+			Processors:     []string{"batch"},
+			Destinations:   []string{"google"},
+		},
+	}
+
+	// Components returned by Bindplane have versions and may be
+	// mixed with components defined by blocks.
+	explicitSource := model.ResourceConfiguration{Name: "files:2"}
+	explicitDestination := model.ResourceConfiguration{ID: "otlp", Name: "otlp:1"}
+	server := func() PipelineComponents {
+		return PipelineComponents{
+			Sources: []model.ResourceConfiguration{
+				explicitSource,
+				{
+					ID:   "source-1",
+					Name: "host:3",
+					Routes: &model.Routes{
+						Traces: []model.Route{{ID: "pipeline-0-traces", Components: []model.ComponentPath{"processors/pipeline-0"}}},
+						Logs:   []model.Route{{ID: "pipeline-0-logs", Components: []model.ComponentPath{"processors/pipeline-0"}}},
+					},
+				},
+			},
+			Processors: []model.ResourceConfiguration{
+				{
+					ID: "pipeline-0",
+					ParameterizedSpec: model.ParameterizedSpec{
+						Processors: []model.ResourceConfiguration{{Name: "batch:1"}},
+					},
+					Routes: &model.Routes{
+						Logs:   []model.Route{{ID: "pipeline-0-logs", Components: []model.ComponentPath{"destinations/pipeline-google"}}},
+						Traces: []model.Route{{ID: "pipeline-0-traces", Components: []model.ComponentPath{"destinations/pipeline-google"}}},
+					},
+				},
+			},
+			Destinations: []model.ResourceConfiguration{
+				{ID: "pipeline-google", Name: "google:4"},
+				explicitDestination,
+			},
+		}
+	}
+
+	remaining, ok := CollapsePipelines(pipelines, server())
+	require.True(t, ok)
+	require.Equal(t, []model.ResourceConfiguration{explicitSource}, remaining.Sources)
+	require.Empty(t, remaining.Processors)
+	require.Equal(t, []model.ResourceConfiguration{explicitDestination}, remaining.Destinations)
+
+	// A processor added outside of Terraform
+	modified := server()
+	modified.Processors[0].Processors = append(modified.Processors[0].Processors, model.ResourceConfiguration{Name: "filter:1"})
+	remaining, ok = CollapsePipelines(pipelines, modified)
+	require.False(t, ok)
+	require.Equal(t, modified, remaining)
+
+	// A route removed outside of Terraform
+	modified = server()
+	modified.Sources[1].Routes.Traces = nil
+	_, ok = CollapsePipelines(pipelines, modified)
+	require.False(t, ok)
+
+	// A destination removed outside of Terraform
+	modified = server()
+	modified.Destinations = modified.Destinations[1:]
+	_, ok = CollapsePipelines(pipelines, modified)
+	require.False(t, ok)
+}
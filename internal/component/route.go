@@ -65,6 +65,44 @@ func ValidateRouteComponents(components []model.ComponentPath) []error {
 	return errs
 }
 
+// RouteComponent returns the route component path of a processor group,
+// destination, or connector with the route ID. kind can be singular or
+// plural, such as "destination" or "destinations".
+func RouteComponent(kind, id string) (model.ComponentPath, error) {
+	prefix, err := RoutePrefix(kind)
+	if err != nil {
+		return "", err
+	}
+	if err := ValidateRouteID(id); err != nil {
+		return "", err
+	}
+	return model.ComponentPath(fmt.Sprintf("%s/%s", prefix, id)), nil
+}
+
+// RoutePrefix returns the route component prefix of a component
+// kind, such as "destinations" for "destination".
+func RoutePrefix(kind string) (string, error) {
+	switch strings.TrimSuffix(kind, "s") {
+	case "processor":
+		return RoutePrefixProcessor, nil
+	case "destination":
+		return RoutePrefixDestination, nil
+	case "connector":
+		return RoutePrefixConnector, nil
+	default:
+		return "", fmt.Errorf("invalid route component kind '%s', must be one of processor, destination, or connector", kind)
+	}
+}
+
+// ValidateRouteID returns an error if id cannot be
+// used as the route ID of a route component.
+func ValidateRouteID(id string) error {
+	if id == "" || strings.Contains(id, "/") {
+		return fmt.Errorf("invalid route ID '%s', must be non-empty and not contain '/'", id)
+	}
+	return nil
+}
+
 // ParseRoutes reads routes from the state and returns
 // them as a model.Routes object.
 //
@@ -123,24 +161,14 @@ func RoutesToState(inRoutes *model.Routes) ([]map[string]any, error) {
 
 	stateRoutes := []map[string]any{}
 
-	routeMap := map[string][]model.Route{
-		RouteTypeLogs:    inRoutes.Logs,
-		RouteTypeMetrics: inRoutes.Metrics,
-		RouteTypeTraces:  inRoutes.Traces,
-	}
-
-	for routeType, routes := range routeMap {
-		if len(routes) > 0 {
-			r := []map[string]any{}
-			for _, route := range routes {
-				r = append(r, map[string]any{
-					"route_id":       route.ID,
-					"telemetry_type": routeType,
-					"components":     route.Components,
-				})
-			}
-			stateRoutes = append(stateRoutes, r...)
-		}
+	// Routes are returned in a consistent order: logs, metrics,
+	// and then traces, each in the order returned by Bindplane.
+	for _, tr := range allRoutes(inRoutes) {
+		stateRoutes = append(stateRoutes, map[string]any{
+			"route_id":       tr.route.ID,
+			"telemetry_type": tr.telemetryType,
+			"components":     tr.route.Components,
+		})
 	}
 
 	return stateRoutes, nil
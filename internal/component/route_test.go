@@ -224,6 +224,43 @@ func TestRoutesToState(t *testing.T) {
 				},
 			},
 		},
+		{
+			"all routes in order",
+			&model.Routes{
+				Traces: []model.Route{
+					{ID: "jaeger", Components: []model.ComponentPath{"destinations/jaeger"}},
+				},
+				Metrics: []model.Route{
+					{ID: "data", Components: []model.ComponentPath{"processors/batcher"}},
+					{ID: "count", Components: []model.ComponentPath{"connectors/count"}},
+				},
+				Logs: []model.Route{
+					{ID: "loki", Components: []model.ComponentPath{"destinations/loki"}},
+				},
+			},
+			[]map[string]any{
+				{
+					"route_id":       "loki",
+					"telemetry_type": "logs",
+					"components":     []model.ComponentPath{"destinations/loki"},
+				},
+				{
+					"route_id":       "data",
+					"telemetry_type": "metrics",
+					"components":     []model.ComponentPath{"processors/batcher"},
+				},
+				{
+					"route_id":       "count",
+					"telemetry_type": "metrics",
+					"components":     []model.ComponentPath{"connectors/count"},
+				},
+				{
+					"route_id":       "jaeger",
+					"telemetry_type": "traces",
+					"components":     []model.ComponentPath{"destinations/jaeger"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -234,3 +271,35 @@ func TestRoutesToState(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteComponent(t *testing.T) {
+	cases := []struct {
+		kind      string
+		id        string
+		expect    model.ComponentPath
+		expectErr string
+	}{
+		{"destination", "google", "destinations/google", ""},
+		{"destinations", "google", "destinations/google", ""},
+		{"processor", "parse", "processors/parse", ""},
+		{"connectors", "count", "connectors/count", ""},
+		{"source", "host", "", "invalid route component kind 'source', must be one of processor, destination, or connector"},
+		{"destination", "", "", "invalid route ID '', must be non-empty and not contain '/'"},
+		{"destination", "a/b", "", "invalid route ID 'a/b', must be non-empty and not contain '/'"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.kind+"/"+tc.id, func(t *testing.T) {
+			path, err := RouteComponent(tc.kind, tc.id)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, path)
+
+			// Route components are valid
+			require.Empty(t, ValidateRouteComponents([]model.ComponentPath{path}))
+		})
+	}
+}
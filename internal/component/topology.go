@@ -0,0 +1,259 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// Topology node kinds
+const (
+	TopologySource         = "source"
+	TopologyProcessorGroup = "processor_group"
+	TopologyConnector      = "connector"
+	TopologyDestination    = "destination"
+
+	// TopologyMissing is a route component which does
+	// not exist in the configuration.
+	TopologyMissing = "missing"
+)
+
+// Topology is the flow of telemetry through a configuration
+type Topology struct {
+	// Name is the name of the configuration
+	Name  string
+	Nodes []TopologyNode
+	Edges []TopologyEdge
+}
+
+// TopologyNode is a source, processor group, connector,
+// or destination.
+type TopologyNode struct {
+	// ID is a unique identifier which is safe to use
+	// in DOT and Mermaid, such as "s0".
+	ID string
+
+	// Kind is one of the topology node kinds, such as "source"
+	Kind string
+
+	// Label is the component name, followed by its processors
+	Label string
+}
+
+// TopologyEdge is a route between two nodes
+type TopologyEdge struct {
+	From string
+	To   string
+
+	// TelemetryType is the telemetry type routed. Empty when a
+	// configuration without routes sends all telemetry from each
+	// source to each destination.
+	TelemetryType string
+}
+
+// ConfigurationTopology returns the topology of a configuration.
+// When telemetryType is set, only routes of that telemetry type
+// are included.
+func ConfigurationTopology(config *model.Configuration, telemetryType string) Topology {
+	t := Topology{Name: config.Name()}
+	paths := map[string]string{}
+
+	add := func(kind, prefix string, components []model.ResourceConfiguration) []string {
+		ids := []string{}
+		for i, c := range components {
+			id := fmt.Sprintf("%s%d", kind[:1], i)
+			t.Nodes = append(t.Nodes, TopologyNode{
+				ID:    id,
+				Kind:  kind,
+				Label: topologyLabel(kind, c),
+			})
+			if prefix != "" && c.ID != "" {
+				paths[fmt.Sprintf("%s/%s", prefix, c.ID)] = id
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	sources := add(TopologySource, "", config.Spec.Sources)
+	processorGroups := add(TopologyProcessorGroup, RoutePrefixProcessor, config.Spec.Processors)
+	connectors := add(TopologyConnector, RoutePrefixConnector, config.Spec.Connectors)
+	destinations := add(TopologyDestination, RoutePrefixDestination, config.Spec.Destinations)
+
+	routed := []struct {
+		ids        []string
+		components []model.ResourceConfiguration
+	}{
+		{sources, config.Spec.Sources},
+		{processorGroups, config.Spec.Processors},
+		{connectors, config.Spec.Connectors},
+	}
+
+	seen := map[TopologyEdge]bool{}
+	addEdge := func(e TopologyEdge) {
+		if telemetryType != "" && e.TelemetryType != "" && e.TelemetryType != telemetryType {
+			return
+		}
+		if seen[e] {
+			return
+		}
+		seen[e] = true
+		t.Edges = append(t.Edges, e)
+	}
+
+	hasRoutes := false
+	missing := 0
+	for _, r := range routed {
+		for i, c := range r.components {
+			for _, tr := range allRoutes(c.Routes) {
+				hasRoutes = true
+				for _, path := range tr.route.Components {
+					to, ok := paths[string(path)]
+					if !ok {
+						to = fmt.Sprintf("m%d", missing)
+						missing++
+						paths[string(path)] = to
+						t.Nodes = append(t.Nodes, TopologyNode{
+							ID:    to,
+							Kind:  TopologyMissing,
+							Label: string(path),
+						})
+					}
+					addEdge(TopologyEdge{From: r.ids[i], To: to, TelemetryType: tr.telemetryType})
+				}
+			}
+		}
+	}
+
+	// Configurations without routes send all telemetry
+	// from each source to each destination.
+	if !hasRoutes {
+		for _, s := range sources {
+			for _, d := range destinations {
+				addEdge(TopologyEdge{From: s, To: d})
+			}
+		}
+	}
+
+	return t
+}
+
+// topologyLabel returns the label of a component: its name or route ID,
+// and the names of its processors.
+func topologyLabel(kind string, c model.ResourceConfiguration) string {
+	label := unversioned(c.Name)
+	switch {
+	case kind == TopologyProcessorGroup:
+		label = c.ID
+	case label == "" && c.Type != "":
+		label = fmt.Sprintf("%s (inline)", unversioned(c.Type))
+	}
+
+	processors := []string{}
+	for _, p := range c.Processors {
+		name := unversioned(p.Name)
+		if name == "" {
+			name = fmt.Sprintf("%s (inline)", unversioned(p.Type))
+		}
+		processors = append(processors, name)
+	}
+	if len(processors) > 0 {
+		label = fmt.Sprintf("%s\n%s", label, strings.Join(processors, " → "))
+	}
+	return label
+}
+
+// topologyColors are the edge colors used for each telemetry type
+var topologyColors = map[string]string{
+	RouteTypeLogs:    "#1f77b4",
+	RouteTypeMetrics: "#2ca02c",
+	RouteTypeTraces:  "#ff7f0e",
+}
+
+// DOT returns the topology as a Graphviz DOT digraph
+func (t Topology) DOT() string {
+	shapes := map[string]string{
+		TopologySource:         "invhouse",
+		TopologyProcessorGroup: "box",
+		TopologyConnector:      "diamond",
+		TopologyDestination:    "house",
+		TopologyMissing:        "octagon",
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(t.Name))
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range t.Nodes {
+		attrs := fmt.Sprintf("label=%s, shape=%s", dotQuote(n.Label), shapes[n.Kind])
+		if n.Kind == TopologyMissing {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", n.ID, attrs)
+	}
+	for _, e := range t.Edges {
+		if e.TelemetryType == "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s, color=%s];\n", e.From, e.To, dotQuote(e.TelemetryType), dotQuote(topologyColors[e.TelemetryType]))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the topology as a Mermaid flowchart
+func (t Topology) Mermaid() string {
+	shapes := map[string][2]string{
+		TopologySource:         {"([", "])"},
+		TopologyProcessorGroup: {"[", "]"},
+		TopologyConnector:      {"{", "}"},
+		TopologyDestination:    {"[(", ")]"},
+		TopologyMissing:        {"[/", "/]"},
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range t.Nodes {
+		shape := shapes[n.Kind]
+		fmt.Fprintf(&b, "  %s%s%s%s\n", n.ID, shape[0], mermaidQuote(n.Label), shape[1])
+	}
+	for i, e := range t.Edges {
+		if e.TelemetryType == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", e.From, e.TelemetryType, e.To)
+		fmt.Fprintf(&b, "  linkStyle %d stroke:%s\n", i, topologyColors[e.TelemetryType])
+	}
+	return b.String()
+}
+
+// dotQuote returns s as a quoted DOT string
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// mermaidQuote returns s as a quoted Mermaid label
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "\n", "<br/>")
+	return `"` + s + `"`
+}
@@ -0,0 +1,166 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func topologyConfiguration() *model.Configuration {
+	return &model.Configuration{
+		ResourceMeta: model.ResourceMeta{
+			Metadata: model.Metadata{Name: "my-config"},
+		},
+		Spec: model.ConfigurationSpec{
+			Sources: []model.ResourceConfiguration{
+				{
+					Name: "host:2",
+					Routes: &model.Routes{
+						Logs:    []model.Route{{ID: "a", Components: []model.ComponentPath{"processors/parse"}}},
+						Metrics: []model.Route{{ID: "b", Components: []model.ComponentPath{"destinations/google", "connectors/count"}}},
+					},
+				},
+			},
+			Processors: []model.ResourceConfiguration{
+				{
+					ID: "parse",
+					ParameterizedSpec: model.ParameterizedSpec{
+						Processors: []model.ResourceConfiguration{
+							{Name: "json:1"},
+							{ParameterizedSpec: model.ParameterizedSpec{Type: "batch:3"}},
+						},
+					},
+					Routes: &model.Routes{
+						Logs: []model.Route{{ID: "c", Components: []model.ComponentPath{"destinations/google", "destinations/typo"}}},
+					},
+				},
+			},
+			Connectors: []model.ResourceConfiguration{
+				{
+					ID:   "count",
+					Name: "count:1",
+					Routes: &model.Routes{
+						Metrics: []model.Route{{ID: "d", Components: []model.ComponentPath{"destinations/google"}}},
+					},
+				},
+			},
+			Destinations: []model.ResourceConfiguration{
+				{ID: "google", Name: "google:4"},
+			},
+		},
+	}
+}
+
+func TestConfigurationTopology(t *testing.T) {
+	topology := ConfigurationTopology(topologyConfiguration(), "")
+	require.Equal(t, "my-config", topology.Name)
+	require.Equal(t, []TopologyNode{
+		{ID: "s0", Kind: TopologySource, Label: "host"},
+		{ID: "p0", Kind: TopologyProcessorGroup, Label: "parse\njson → batch (inline)"},
+		{ID: "c0", Kind: TopologyConnector, Label: "count"},
+		{ID: "d0", Kind: TopologyDestination, Label: "google"},
+		{ID: "m0", Kind: TopologyMissing, Label: "destinations/typo"},
+	}, topology.Nodes)
+	require.Equal(t, []TopologyEdge{
+		{From: "s0", To: "p0", TelemetryType: "logs"},
+		{From: "s0", To: "d0", TelemetryType: "metrics"},
+		{From: "s0", To: "c0", TelemetryType: "metrics"},
+		{From: "p0", To: "d0", TelemetryType: "logs"},
+		{From: "p0", To: "m0", TelemetryType: "logs"},
+		{From: "c0", To: "d0", TelemetryType: "metrics"},
+	}, topology.Edges)
+
+	metrics := ConfigurationTopology(topologyConfiguration(), RouteTypeMetrics)
+	require.Equal(t, []TopologyEdge{
+		{From: "s0", To: "d0", TelemetryType: "metrics"},
+		{From: "s0", To: "c0", TelemetryType: "metrics"},
+		{From: "c0", To: "d0", TelemetryType: "metrics"},
+	}, metrics.Edges)
+}
+
+func TestConfigurationTopologyWithoutRoutes(t *testing.T) {
+	config := &model.Configuration{
+		Spec: model.ConfigurationSpec{
+			Sources: []model.ResourceConfiguration{
+				{Name: "host:1"},
+				{ParameterizedSpec: model.ParameterizedSpec{Type: "otlp:1"}},
+			},
+			Destinations: []model.ResourceConfiguration{
+				{Name: "google:1"},
+			},
+		},
+	}
+
+	topology := ConfigurationTopology(config, RouteTypeTraces)
+	require.Equal(t, "otlp (inline)", topology.Nodes[1].Label)
+	require.Equal(t, []TopologyEdge{
+		{From: "s0", To: "d0"},
+		{From: "s1", To: "d0"},
+	}, topology.Edges)
+}
+
+func TestTopologyDOT(t *testing.T) {
+	topology := Topology{
+		Name: `my "config"`,
+		Nodes: []TopologyNode{
+			{ID: "s0", Kind: TopologySource, Label: "host"},
+			{ID: "d0", Kind: TopologyDestination, Label: "google\nbatch"},
+			{ID: "m0", Kind: TopologyMissing, Label: "destinations/typo"},
+		},
+		Edges: []TopologyEdge{
+			{From: "s0", To: "d0", TelemetryType: "logs"},
+			{From: "s0", To: "m0"},
+		},
+	}
+
+	expect := `digraph "my \"config\"" {
+  rankdir=LR;
+  s0 [label="host", shape=invhouse];
+  d0 [label="google\nbatch", shape=house];
+  m0 [label="destinations/typo", shape=octagon, style=dashed];
+  s0 -> d0 [label="logs", color="#1f77b4"];
+  s0 -> m0;
+}
+`
+	require.Equal(t, expect, topology.DOT())
+}
+
+func TestTopologyMermaid(t *testing.T) {
+	topology := Topology{
+		Name: "my-config",
+		Nodes: []TopologyNode{
+			{ID: "s0", Kind: TopologySource, Label: `host "a"`},
+			{ID: "p0", Kind: TopologyProcessorGroup, Label: "parse\nbatch"},
+			{ID: "d0", Kind: TopologyDestination, Label: "google"},
+		},
+		Edges: []TopologyEdge{
+			{From: "s0", To: "p0"},
+			{From: "p0", To: "d0", TelemetryType: "traces"},
+		},
+	}
+
+	expect := `flowchart LR
+  s0(["host #quot;a#quot;"])
+  p0["parse<br/>batch"]
+  d0[("google")]
+  s0 --> p0
+  p0 -->|traces| d0
+  linkStyle 1 stroke:#ff7f0e
+`
+	require.Equal(t, expect, topology.Mermaid())
+}
@@ -0,0 +1,101 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// UpgradeV1Components returns the sources and destinations of a v1
+// configuration with the route IDs and routes of the equivalent v2
+// configuration. v1 configurations send each telemetry type from each
+// source to each destination, so each source is given a route for each
+// telemetry type, named after the telemetry type, to the destinations.
+//
+// Destinations without an ID are given a route ID generated from their
+// name, or inline type. telemetryTypes returns the telemetry types
+// supported by a source or destination, or nil if they are not known.
+// Telemetry types are only routed between components which support them.
+func UpgradeV1Components(sources, destinations []model.ResourceConfiguration, telemetryTypes func(c model.ResourceConfiguration, destination bool) []string) ([]model.ResourceConfiguration, []model.ResourceConfiguration) {
+	upgradedDestinations := []model.ResourceConfiguration{}
+	used := map[string]bool{}
+	for _, d := range destinations {
+		used[d.ID] = d.ID != ""
+	}
+	for _, d := range destinations {
+		if d.ID == "" {
+			d.ID = uniqueRouteID(destinationBaseID(d), used)
+		}
+		upgradedDestinations = append(upgradedDestinations, d)
+	}
+
+	upgradedSources := []model.ResourceConfiguration{}
+	for _, s := range sources {
+		source := RouteNode{TelemetryTypes: telemetryTypes(s, false)}
+		routes := &model.Routes{}
+		for _, t := range []string{RouteTypeLogs, RouteTypeMetrics, RouteTypeTraces} {
+			if !source.supports(t) {
+				continue
+			}
+			components := []model.ComponentPath{}
+			for _, d := range upgradedDestinations {
+				destination := RouteNode{TelemetryTypes: telemetryTypes(d, true)}
+				if destination.supports(t) {
+					components = append(components, model.ComponentPath(fmt.Sprintf("%s/%s", RoutePrefixDestination, d.ID)))
+				}
+			}
+			if len(components) == 0 {
+				continue
+			}
+
+			route := model.Route{ID: t, Components: components}
+			switch t {
+			case RouteTypeLogs:
+				routes.Logs = append(routes.Logs, route)
+			case RouteTypeMetrics:
+				routes.Metrics = append(routes.Metrics, route)
+			case RouteTypeTraces:
+				routes.Traces = append(routes.Traces, route)
+			}
+		}
+
+		s.Routes = routes
+		upgradedSources = append(upgradedSources, s)
+	}
+
+	return upgradedSources, upgradedDestinations
+}
+
+// destinationBaseID returns the route ID to generate for a
+// destination: its name, or inline type, without a version.
+func destinationBaseID(d model.ResourceConfiguration) string {
+	if name := unversioned(d.Name); name != "" {
+		return name
+	}
+	return unversioned(d.Type)
+}
+
+// uniqueRouteID returns id, or id followed by a number if id
+// is already used, and marks the returned ID as used.
+func uniqueRouteID(id string, used map[string]bool) string {
+	unique := id
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", id, n)
+	}
+	used[unique] = true
+	return unique
+}
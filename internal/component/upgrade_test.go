@@ -0,0 +1,85 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeV1Components(t *testing.T) {
+	sources := []model.ResourceConfiguration{
+		{Name: "host:2"},
+		{Name: "journald:1"},
+	}
+	destinations := []model.ResourceConfiguration{
+		{Name: "google:3"},
+		{Name: "google:3"},
+		{ID: "google-2", Name: "otlp:1"},
+		{ParameterizedSpec: model.ParameterizedSpec{Type: "loki:1"}},
+	}
+
+	telemetryTypes := func(c model.ResourceConfiguration, destination bool) []string {
+		switch {
+		case c.Name == "host:2" && !destination:
+			return []string{"Metrics", "Logs"}
+		case c.Name == "journald:1" && !destination:
+			return []string{"Logs"}
+		case c.Type == "loki:1" && destination:
+			return []string{"Logs"}
+		default:
+			return nil
+		}
+	}
+
+	upgradedSources, upgradedDestinations := UpgradeV1Components(sources, destinations, telemetryTypes)
+
+	require.Equal(t, []model.ResourceConfiguration{
+		{ID: "google", Name: "google:3"},
+		{ID: "google-3", Name: "google:3"},
+		{ID: "google-2", Name: "otlp:1"},
+		{ID: "loki", ParameterizedSpec: model.ParameterizedSpec{Type: "loki:1"}},
+	}, upgradedDestinations)
+
+	all := []model.ComponentPath{"destinations/google", "destinations/google-3", "destinations/google-2", "destinations/loki"}
+	withoutLoki := all[:3]
+	require.Equal(t, []model.ResourceConfiguration{
+		{
+			Name: "host:2",
+			Routes: &model.Routes{
+				Logs:    []model.Route{{ID: "logs", Components: all}},
+				Metrics: []model.Route{{ID: "metrics", Components: withoutLoki}},
+			},
+		},
+		{
+			Name: "journald:1",
+			Routes: &model.Routes{
+				Logs: []model.Route{{ID: "logs", Components: all}},
+			},
+		},
+	}, upgradedSources)
+
+	// The upgraded components are a valid route graph
+	nodes := []RouteNode{}
+	for i, s := range upgradedSources {
+		nodes = append(nodes, RouteNode{Block: string(rune('a' + i)), Routes: s.Routes})
+	}
+	for _, d := range upgradedDestinations {
+		nodes = append(nodes, RouteNode{Block: d.ID, Prefix: RoutePrefixDestination, RouteID: d.ID})
+	}
+	require.Empty(t, ValidateRouteGraph(nodes))
+}
@@ -29,9 +29,20 @@ type ResourceConfig struct {
 	// attached to the configuration
 	Name string
 
+	// Type and Parameters define an inline resource, which is defined
+	// within the configuration instead of referencing a library resource
+	// by name. Only used when Name is empty.
+	Type       string
+	Parameters []model.Parameter
+
 	// A list of processor names to attach to the resource
 	Processors []string
 
+	// InlineProcessors are processors to attach to the resource, each
+	// referencing a library processor by name or defining an inline
+	// processor. They are attached after Processors.
+	InlineProcessors []ResourceConfig
+
 	// RouteID is the ID to use when routing to this resource
 	RouteID string
 
@@ -150,6 +161,16 @@ func WithAdvancedParameters(parameters []model.Parameter) Option {
 	}
 }
 
+// WithRaw is an Option that configures a raw configuration's
+// OpenTelemetry YAML. Raw configurations do not have sources,
+// destinations, or other components.
+func WithRaw(raw string) Option {
+	return func(c *model.Configuration) error {
+		c.Spec.Raw = raw
+		return nil
+	}
+}
+
 // NewV1 takes configuration options and returns a Bindplane configuration
 func NewV1(options ...Option) (*model.Configuration, error) {
 	const (
@@ -237,16 +258,23 @@ func withResourcesByName(r []ResourceConfig) []model.ResourceConfiguration {
 			processorResources = append(processorResources, processor)
 		}
 
+		for _, p := range r.InlineProcessors {
+			processorResources = append(processorResources, model.ResourceConfiguration{
+				Name:              p.Name,
+				ParameterizedSpec: inlineSpec(p),
+			})
+		}
+
 		routeID := r.RouteID
 
-		// Build source resource with name and list
-		// of processor resources
+		// Build source resource with name or inline type and
+		// parameters, and list of processor resources
+		spec := inlineSpec(r)
+		spec.Processors = processorResources
 		r := model.ResourceConfiguration{
-			Name: r.Name,
-			ParameterizedSpec: model.ParameterizedSpec{
-				Processors: processorResources,
-			},
-			Routes: r.Routes,
+			Name:              r.Name,
+			ParameterizedSpec: spec,
+			Routes:            r.Routes,
 		}
 		if routeID != "" {
 			r.ID = routeID
@@ -256,3 +284,15 @@ func withResourcesByName(r []ResourceConfig) []model.ResourceConfiguration {
 	}
 	return resources
 }
+
+// inlineSpec returns the type and parameters of an inline resource.
+// Resources referenced by name have an empty spec.
+func inlineSpec(r ResourceConfig) model.ParameterizedSpec {
+	if r.Name != "" {
+		return model.ParameterizedSpec{}
+	}
+	return model.ParameterizedSpec{
+		Type:       r.Type,
+		Parameters: r.Parameters,
+	}
+}
@@ -95,6 +95,61 @@ func TestNewV1(t *testing.T) {
 				},
 			},
 		},
+		{
+			"inline-sources",
+			func() Option {
+				r := []ResourceConfig{
+					{
+						Type: "otlp",
+						Parameters: []model.Parameter{
+							{Name: "grpc_port", Value: 4317},
+						},
+						InlineProcessors: []ResourceConfig{
+							{Name: "batch"},
+							{
+								Type: "add_fields",
+								Parameters: []model.Parameter{
+									{Name: "enable_logs", Value: true},
+								},
+							},
+						},
+					},
+				}
+				return WithSourcesByName(r)
+			}(),
+			&model.Configuration{
+				ResourceMeta: model.ResourceMeta{
+					APIVersion: "bindplane.observiq.com/v1",
+					Kind:       model.KindConfiguration,
+				},
+				Spec: model.ConfigurationSpec{
+					ContentType: "text/yaml",
+					Sources: []model.ResourceConfiguration{
+						{
+							ParameterizedSpec: model.ParameterizedSpec{
+								Type: "otlp",
+								Parameters: []model.Parameter{
+									{Name: "grpc_port", Value: 4317},
+								},
+								Processors: []model.ResourceConfiguration{
+									{
+										Name: "batch",
+									},
+									{
+										ParameterizedSpec: model.ParameterizedSpec{
+											Type: "add_fields",
+											Parameters: []model.Parameter{
+												{Name: "enable_logs", Value: true},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			"destinations",
 			func() Option {
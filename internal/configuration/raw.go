@@ -0,0 +1,165 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateRaw validates a raw OpenTelemetry configuration. The
+// configuration must parse, define receivers and exporters, and
+// define service pipelines which only reference defined components.
+func ValidateRaw(raw string) error {
+	config := struct {
+		Receivers  map[string]any `yaml:"receivers"`
+		Processors map[string]any `yaml:"processors"`
+		Exporters  map[string]any `yaml:"exporters"`
+		Connectors map[string]any `yaml:"connectors"`
+		Extensions map[string]any `yaml:"extensions"`
+		Service    struct {
+			Extensions []string `yaml:"extensions"`
+			Pipelines  map[string]struct {
+				Receivers  []string `yaml:"receivers"`
+				Processors []string `yaml:"processors"`
+				Exporters  []string `yaml:"exporters"`
+			} `yaml:"pipelines"`
+		} `yaml:"service"`
+	}{}
+
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		return fmt.Errorf("failed to parse OpenTelemetry configuration: %w", err)
+	}
+
+	var errs error
+	if len(config.Receivers) == 0 {
+		errs = errors.Join(errs, errors.New("receivers must define at least one receiver"))
+	}
+	if len(config.Exporters) == 0 {
+		errs = errors.Join(errs, errors.New("exporters must define at least one exporter"))
+	}
+	if len(config.Service.Pipelines) == 0 {
+		errs = errors.Join(errs, errors.New("service.pipelines must define at least one pipeline"))
+	}
+
+	// Connectors act as both an exporter and a receiver
+	check := func(path, section string, ids []string, defined ...map[string]any) {
+		for _, id := range ids {
+			found := false
+			for _, d := range defined {
+				if _, ok := d[id]; ok {
+					found = true
+				}
+			}
+			if !found {
+				errs = errors.Join(errs, fmt.Errorf("%s references %s '%s' which is not defined", path, section, id))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(config.Service.Pipelines))
+	for name := range config.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := config.Service.Pipelines[name]
+		path := fmt.Sprintf("service.pipelines.%s", name)
+		if len(p.Receivers) == 0 {
+			errs = errors.Join(errs, fmt.Errorf("%s must have at least one receiver", path))
+		}
+		if len(p.Exporters) == 0 {
+			errs = errors.Join(errs, fmt.Errorf("%s must have at least one exporter", path))
+		}
+		check(path, "receiver", p.Receivers, config.Receivers, config.Connectors)
+		check(path, "processor", p.Processors, config.Processors)
+		check(path, "exporter", p.Exporters, config.Exporters, config.Connectors)
+	}
+	check("service", "extension", config.Service.Extensions, config.Extensions)
+
+	return errs
+}
+
+// EquivalentRaw returns true if two raw OpenTelemetry configurations
+// are equivalent. Whitespace, key order, and comments are ignored.
+func EquivalentRaw(a, b string) bool {
+	var aData, bData any
+	if yaml.Unmarshal([]byte(a), &aData) != nil || yaml.Unmarshal([]byte(b), &bData) != nil {
+		return normalizeWhitespace(a) == normalizeWhitespace(b)
+	}
+	return reflect.DeepEqual(aData, bData)
+}
+
+// normalizeWhitespace removes trailing whitespace and blank lines
+func normalizeWhitespace(s string) string {
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RawComponentIDs are the IDs of the components defined by a raw
+// OpenTelemetry configuration, such as "otlp" or "batch/logs".
+type RawComponentIDs struct {
+	Receivers  []string
+	Processors []string
+	Exporters  []string
+	Connectors []string
+	Extensions []string
+}
+
+// RawComponents returns the sorted IDs of the components defined
+// by a raw OpenTelemetry configuration.
+func RawComponents(raw string) (RawComponentIDs, error) {
+	config := struct {
+		Receivers  map[string]any `yaml:"receivers"`
+		Processors map[string]any `yaml:"processors"`
+		Exporters  map[string]any `yaml:"exporters"`
+		Connectors map[string]any `yaml:"connectors"`
+		Extensions map[string]any `yaml:"extensions"`
+	}{}
+
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		return RawComponentIDs{}, fmt.Errorf("failed to parse OpenTelemetry configuration: %w", err)
+	}
+
+	ids := func(m map[string]any) []string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	return RawComponentIDs{
+		Receivers:  ids(config.Receivers),
+		Processors: ids(config.Processors),
+		Exporters:  ids(config.Exporters),
+		Connectors: ids(config.Connectors),
+		Extensions: ids(config.Extensions),
+	}, nil
+}
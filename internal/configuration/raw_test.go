@@ -0,0 +1,147 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRaw = `receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+exporters:
+  debug:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    logs:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`
+
+func TestValidateRaw(t *testing.T) {
+	cases := []struct {
+		name      string
+		raw       string
+		expectErr string
+	}{
+		{
+			"valid",
+			testRaw,
+			"",
+		},
+		{
+			"connector",
+			`receivers: {otlp: {}}
+exporters: {debug: {}}
+connectors: {count: {}}
+service:
+  pipelines:
+    logs: {receivers: [otlp], exporters: [count]}
+    metrics: {receivers: [count], exporters: [debug]}
+`,
+			"",
+		},
+		{
+			"invalid yaml",
+			"receivers: [",
+			"failed to parse OpenTelemetry configuration",
+		},
+		{
+			"empty",
+			"",
+			"receivers must define at least one receiver\nexporters must define at least one exporter\nservice.pipelines must define at least one pipeline",
+		},
+		{
+			"undefined components",
+			`receivers: {otlp: {}}
+exporters: {debug: {}}
+service:
+  extensions: [pprof]
+  pipelines:
+    logs: {receivers: [filelog], processors: [batch], exporters: []}
+`,
+			"service.pipelines.logs must have at least one exporter\n" +
+				"service.pipelines.logs references receiver 'filelog' which is not defined\n" +
+				"service.pipelines.logs references processor 'batch' which is not defined\n" +
+				"service references extension 'pprof' which is not defined",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRaw(tc.raw)
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectErr)
+		})
+	}
+}
+
+func TestEquivalentRaw(t *testing.T) {
+	reformatted := `
+# Collect OTLP logs
+receivers:
+    otlp:
+        protocols:
+            grpc:
+processors: {batch: }
+exporters:
+    debug:
+extensions:
+    health_check:
+service:
+    pipelines:
+        logs:
+            receivers: [otlp]
+            processors: [batch]
+            exporters: [debug]
+    extensions: [health_check]
+`
+	require.True(t, EquivalentRaw(testRaw, reformatted))
+	require.True(t, EquivalentRaw(testRaw, testRaw+"\n\n"))
+	require.False(t, EquivalentRaw(testRaw, `receivers: {otlp: {}}`))
+	require.True(t, EquivalentRaw("a: [\n", "a: [  \n\n"))
+	require.False(t, EquivalentRaw("a: [\n", "b: [\n"))
+}
+
+func TestRawComponents(t *testing.T) {
+	ids, err := RawComponents(testRaw + `
+connectors:
+  count:
+`)
+	require.NoError(t, err)
+	require.Equal(t, RawComponentIDs{
+		Receivers:  []string{"otlp"},
+		Processors: []string{"batch"},
+		Exporters:  []string{"debug"},
+		Connectors: []string{"count"},
+		Extensions: []string{"health_check"},
+	}, ids)
+
+	_, err = RawComponents("receivers: [")
+	require.Error(t, err)
+}
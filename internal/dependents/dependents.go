@@ -0,0 +1,246 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dependents provides functions for finding the Bindplane
+// resources which reference a component.
+package dependents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+const (
+	// LocationSource is a configuration source
+	LocationSource = "source"
+
+	// LocationSourceProcessor is a processor attached to a configuration source
+	LocationSourceProcessor = "source_processor"
+
+	// LocationDestination is a configuration destination
+	LocationDestination = "destination"
+
+	// LocationDestinationProcessor is a processor attached to a configuration destination
+	LocationDestinationProcessor = "destination_processor"
+
+	// LocationProcessorGroup is a processor within a configuration processor group
+	LocationProcessorGroup = "processor_group"
+
+	// LocationConnector is a configuration connector
+	LocationConnector = "connector"
+
+	// LocationExtension is a configuration extension
+	LocationExtension = "extension"
+
+	// LocationRoute is a route targeting the component
+	LocationRoute = "route"
+
+	// LocationProcessorBundle is a processor within a processor bundle
+	LocationProcessorBundle = "processor_bundle"
+)
+
+// Reference describes where a resource references a component.
+type Reference struct {
+	// Kind is the kind of the referencing resource, Configuration
+	// or Processor (processor bundles).
+	Kind model.Kind
+
+	// Name is the name of the referencing resource
+	Name string
+
+	// Location is the type of block the component is referenced from
+	Location string
+
+	// Path is the path to the reference within the referencing
+	// resource's spec. For example, "sources[0].processors[1]".
+	Path string
+
+	// RouteID is the route ID of the reference, if it has one
+	RouteID string
+}
+
+// InConfiguration returns all references to the named component
+// of the given kind within a configuration.
+func InConfiguration(c *model.Configuration, kind model.Kind, name string) []Reference {
+	if c == nil {
+		return nil
+	}
+
+	f := &finder{
+		kind:    model.KindConfiguration,
+		name:    c.Name(),
+		refs:    []Reference{},
+		targets: map[string]struct{}{},
+	}
+
+	switch kind {
+	case model.KindSource:
+		f.components("sources", LocationSource, c.Spec.Sources, name)
+	case model.KindDestination:
+		f.components("destinations", LocationDestination, c.Spec.Destinations, name)
+		f.targetIDs(c.Spec.Destinations, name)
+		f.routes(c, "destinations")
+	case model.KindConnector:
+		f.components("connectors", LocationConnector, c.Spec.Connectors, name)
+		f.targetIDs(c.Spec.Connectors, name)
+		f.routes(c, "connectors")
+	case model.KindExtension:
+		f.components("extensions", LocationExtension, c.Spec.Extensions, name)
+	case model.KindProcessor:
+		f.processors("sources", LocationSourceProcessor, c.Spec.Sources, name)
+		f.processors("destinations", LocationDestinationProcessor, c.Spec.Destinations, name)
+		f.processors("processors", LocationProcessorGroup, c.Spec.Processors, name)
+		f.routes(c, "processors")
+	}
+
+	return f.refs
+}
+
+// InProcessorBundle returns all references to the named processor
+// within a processor bundle.
+func InProcessorBundle(p *model.Processor, name string) []Reference {
+	if p == nil {
+		return nil
+	}
+
+	refs := []Reference{}
+	for i, proc := range p.Spec.Processors {
+		if baseName(proc.Name) != name {
+			continue
+		}
+		refs = append(refs, Reference{
+			Kind:     model.KindProcessor,
+			Name:     p.Name(),
+			Location: LocationProcessorBundle,
+			Path:     fmt.Sprintf("processors[%d]", i),
+		})
+	}
+	return refs
+}
+
+// finder collects references within a single configuration
+type finder struct {
+	kind model.Kind
+	name string
+	refs []Reference
+
+	// targets are the route IDs of the component within
+	// the configuration. Routes to these IDs are references
+	// to the component.
+	targets map[string]struct{}
+}
+
+func (f *finder) add(location, path, routeID string) {
+	f.refs = append(f.refs, Reference{
+		Kind:     f.kind,
+		Name:     f.name,
+		Location: location,
+		Path:     path,
+		RouteID:  routeID,
+	})
+}
+
+// components finds resource configurations referencing the component by name
+func (f *finder) components(field, location string, resources []model.ResourceConfiguration, name string) {
+	for i, r := range resources {
+		if baseName(r.Name) == name {
+			f.add(location, fmt.Sprintf("%s[%d]", field, i), r.ID)
+		}
+	}
+}
+
+// processors finds processors referencing the component by name
+// within the given resource configurations. Processor groups are
+// routed to by their ID, so processor groups containing the processor
+// are added to the route targets.
+func (f *finder) processors(field, location string, resources []model.ResourceConfiguration, name string) {
+	for i, r := range resources {
+		for j, p := range r.Processors {
+			if baseName(p.Name) != name {
+				continue
+			}
+			f.add(location, fmt.Sprintf("%s[%d].processors[%d]", field, i, j), r.ID)
+			if location == LocationProcessorGroup && r.ID != "" {
+				f.targets[r.ID] = struct{}{}
+			}
+		}
+	}
+}
+
+// targetIDs saves the route IDs of components matching name
+func (f *finder) targetIDs(resources []model.ResourceConfiguration, name string) {
+	for _, r := range resources {
+		if baseName(r.Name) == name && r.ID != "" {
+			f.targets[r.ID] = struct{}{}
+		}
+	}
+}
+
+// routes finds routes to any of the target route IDs using the given
+// component path prefix, such as "destinations".
+func (f *finder) routes(c *model.Configuration, prefix string) {
+	if len(f.targets) == 0 {
+		return
+	}
+
+	fields := []struct {
+		name      string
+		resources []model.ResourceConfiguration
+	}{
+		{"sources", c.Spec.Sources},
+		{"processors", c.Spec.Processors},
+		{"connectors", c.Spec.Connectors},
+	}
+
+	for _, field := range fields {
+		for i, r := range field.resources {
+			if r.Routes == nil {
+				continue
+			}
+
+			routeTypes := []struct {
+				name   string
+				routes []model.Route
+			}{
+				{"logs", r.Routes.Logs},
+				{"metrics", r.Routes.Metrics},
+				{"traces", r.Routes.Traces},
+			}
+
+			for _, routeType := range routeTypes {
+				for j, route := range routeType.routes {
+					for k, component := range route.Components {
+						p, id, ok := strings.Cut(string(component), "/")
+						if !ok || p != prefix {
+							continue
+						}
+						if _, ok := f.targets[id]; !ok {
+							continue
+						}
+						path := fmt.Sprintf("%s[%d].routes.%s[%d].components[%d]", field.name, i, routeType.name, j, k)
+						f.add(LocationRoute, path, route.ID)
+					}
+				}
+			}
+		}
+	}
+}
+
+// baseName returns a resource name without its version suffix,
+// for example "my-destination:2" becomes "my-destination".
+func baseName(name string) string {
+	return strings.Split(name, ":")[0]
+}
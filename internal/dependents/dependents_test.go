@@ -0,0 +1,207 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependents
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfiguration() *model.Configuration {
+	return &model.Configuration{
+		ResourceMeta: model.ResourceMeta{
+			Kind: model.KindConfiguration,
+			Metadata: model.Metadata{
+				Name: "my-config",
+			},
+		},
+		Spec: model.ConfigurationSpec{
+			Sources: []model.ResourceConfiguration{
+				{
+					Name: "otlp:1",
+					ParameterizedSpec: model.ParameterizedSpec{
+						Processors: []model.ResourceConfiguration{
+							{Name: "batch:2"},
+						},
+					},
+					Routes: &model.Routes{
+						Logs: []model.Route{
+							{
+								ID:         "logs",
+								Components: []model.ComponentPath{"processors/group-1", "destinations/loki-1"},
+							},
+						},
+						Traces: []model.Route{
+							{
+								ID:         "traces",
+								Components: []model.ComponentPath{"connectors/router-1"},
+							},
+						},
+					},
+				},
+			},
+			Processors: []model.ResourceConfiguration{
+				{
+					ID: "group-1",
+					ParameterizedSpec: model.ParameterizedSpec{
+						Processors: []model.ResourceConfiguration{
+							{Name: "filter"},
+							{Name: "batch"},
+						},
+					},
+					Routes: &model.Routes{
+						Logs: []model.Route{
+							{
+								ID:         "group-logs",
+								Components: []model.ComponentPath{"destinations/loki-2"},
+							},
+						},
+					},
+				},
+			},
+			Connectors: []model.ResourceConfiguration{
+				{
+					ID:   "router-1",
+					Name: "router",
+					Routes: &model.Routes{
+						Traces: []model.Route{
+							{
+								ID:         "router-traces",
+								Components: []model.ComponentPath{"destinations/jaeger-1"},
+							},
+						},
+					},
+				},
+			},
+			Destinations: []model.ResourceConfiguration{
+				{ID: "loki-1", Name: "loki:3"},
+				{ID: "loki-2", Name: "loki:3"},
+				{
+					ID:   "jaeger-1",
+					Name: "jaeger",
+					ParameterizedSpec: model.ParameterizedSpec{
+						Processors: []model.ResourceConfiguration{
+							{Name: "batch"},
+						},
+					},
+				},
+			},
+			Extensions: []model.ResourceConfiguration{
+				{Name: "pprof:1"},
+			},
+		},
+	}
+}
+
+func TestInConfiguration(t *testing.T) {
+	cases := []struct {
+		name     string
+		kind     model.Kind
+		resource string
+		expected []Reference
+	}{
+		{
+			"source",
+			model.KindSource,
+			"otlp",
+			[]Reference{
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationSource, Path: "sources[0]"},
+			},
+		},
+		{
+			"destination with routes",
+			model.KindDestination,
+			"loki",
+			[]Reference{
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationDestination, Path: "destinations[0]", RouteID: "loki-1"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationDestination, Path: "destinations[1]", RouteID: "loki-2"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationRoute, Path: "sources[0].routes.logs[0].components[1]", RouteID: "logs"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationRoute, Path: "processors[0].routes.logs[0].components[0]", RouteID: "group-logs"},
+			},
+		},
+		{
+			"connector",
+			model.KindConnector,
+			"router",
+			[]Reference{
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationConnector, Path: "connectors[0]", RouteID: "router-1"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationRoute, Path: "sources[0].routes.traces[0].components[0]", RouteID: "traces"},
+			},
+		},
+		{
+			"processor",
+			model.KindProcessor,
+			"batch",
+			[]Reference{
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationSourceProcessor, Path: "sources[0].processors[0]"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationDestinationProcessor, Path: "destinations[2].processors[0]", RouteID: "jaeger-1"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationProcessorGroup, Path: "processors[0].processors[1]", RouteID: "group-1"},
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationRoute, Path: "sources[0].routes.logs[0].components[0]", RouteID: "logs"},
+			},
+		},
+		{
+			"extension",
+			model.KindExtension,
+			"pprof",
+			[]Reference{
+				{Kind: model.KindConfiguration, Name: "my-config", Location: LocationExtension, Path: "extensions[0]"},
+			},
+		},
+		{
+			"not referenced",
+			model.KindDestination,
+			"otlp",
+			[]Reference{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs := InConfiguration(testConfiguration(), tc.kind, tc.resource)
+			require.Equal(t, tc.expected, refs)
+		})
+	}
+}
+
+func TestInConfigurationNil(t *testing.T) {
+	require.Nil(t, InConfiguration(nil, model.KindSource, "otlp"))
+}
+
+func TestInProcessorBundle(t *testing.T) {
+	bundle := &model.Processor{
+		ResourceMeta: model.ResourceMeta{
+			Kind: model.KindProcessor,
+			Metadata: model.Metadata{
+				Name: "my-bundle",
+			},
+		},
+		Spec: model.ParameterizedSpec{
+			Type: "processor_bundle",
+			Processors: []model.ResourceConfiguration{
+				{Name: "filter:1"},
+				{Name: "batch:4"},
+			},
+		},
+	}
+
+	require.Equal(t, []Reference{
+		{Kind: model.KindProcessor, Name: "my-bundle", Location: LocationProcessorBundle, Path: "processors[1]"},
+	}, InProcessorBundle(bundle, "batch"))
+
+	require.Equal(t, []Reference{}, InProcessorBundle(bundle, "transform"))
+	require.Nil(t, InProcessorBundle(nil, "batch"))
+}
@@ -0,0 +1,227 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifest provides functions for parsing and comparing
+// Bindplane resource manifests.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"gopkg.in/yaml.v3"
+)
+
+// managedMetadata are the metadata fields which are compared. Other
+// fields, such as id and version, are managed by Bindplane.
+var managedMetadata = []string{"name", "displayName", "description", "labels"}
+
+// Manifest is a Bindplane resource manifest with apiVersion, kind,
+// metadata, and spec fields. Values are normalized to their json
+// representation so that manifests can be compared with reflect.DeepEqual.
+type Manifest map[string]any
+
+// Parse parses a YAML or JSON manifest containing a single resource.
+func Parse(s string) (Manifest, error) {
+	dec := yaml.NewDecoder(strings.NewReader(s))
+
+	raw := map[string]any{}
+	if err := dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("manifest is empty")
+		}
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	var extra any
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, errors.New("manifest must contain a single resource")
+	}
+
+	m, err := normalize(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FromAnyResource returns the manifest of a resource read from
+// Bindplane. Metadata managed by Bindplane is omitted.
+func FromAnyResource(r *model.AnyResource) (Manifest, error) {
+	m, err := normalize(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s': %w", r.Kind, r.Name(), err)
+	}
+	return m.managed(), nil
+}
+
+// AnyResource returns the manifest as a model.AnyResource
+// suitable for client.Apply.
+func (m Manifest) AnyResource() (*model.AnyResource, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &model.AnyResource{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("convert manifest to resource: %w", err)
+	}
+	return r, nil
+}
+
+// Kind returns the manifest's kind
+func (m Manifest) Kind() model.Kind {
+	s, _ := m["kind"].(string)
+	return model.Kind(s)
+}
+
+// Name returns the manifest's metadata.name
+func (m Manifest) Name() string {
+	metadata, _ := m["metadata"].(map[string]any)
+	s, _ := metadata["name"].(string)
+	return s
+}
+
+// String returns the manifest as YAML
+func (m Manifest) String() string {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string]any(m)); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// Equal returns true if the manifests describe the same resource.
+// Formatting, key order, and metadata managed by Bindplane are ignored.
+func Equal(a, b Manifest) bool {
+	return reflect.DeepEqual(a.managed(), b.managed())
+}
+
+// Contains returns true if every field set in the manifest m has the
+// same value in the manifest read from Bindplane. Fields Bindplane adds,
+// such as defaulted spec fields, are ignored.
+func Contains(read, m Manifest) bool {
+	return ContainsValue(map[string]any(read.managed()), map[string]any(m.managed()))
+}
+
+// ContainsValue returns true if every field set in value has the same
+// value in read. Values must be in their json representation.
+func ContainsValue(read, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return read == nil || isEmpty(read)
+	case map[string]any:
+		r, ok := read.(map[string]any)
+		if !ok {
+			return read == nil && len(v) == 0
+		}
+		for k, field := range v {
+			if !ContainsValue(r[k], field) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		r, ok := read.([]any)
+		if !ok {
+			return read == nil && len(v) == 0
+		}
+		if len(r) != len(v) {
+			return false
+		}
+		for i := range v {
+			if !ContainsValue(r[i], v[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(read, value)
+	}
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+// managed returns a copy of the manifest with only apiVersion, kind,
+// spec, and the metadata fields managed by the manifest.
+func (m Manifest) managed() Manifest {
+	out := Manifest{}
+	for _, k := range []string{"apiVersion", "kind", "spec"} {
+		if v, ok := m[k]; ok {
+			out[k] = v
+		}
+	}
+
+	if metadata, ok := m["metadata"].(map[string]any); ok {
+		managed := map[string]any{}
+		for _, k := range managedMetadata {
+			if v, ok := metadata[k]; ok && !isEmpty(v) {
+				managed[k] = v
+			}
+		}
+		out["metadata"] = managed
+	}
+	return out
+}
+
+func (m Manifest) validate() error {
+	var errs error
+	if s, _ := m["apiVersion"].(string); s == "" {
+		errs = errors.Join(errs, errors.New("manifest apiVersion is required"))
+	}
+	if m.Kind() == "" {
+		errs = errors.Join(errs, errors.New("manifest kind is required"))
+	}
+	if m.Name() == "" {
+		errs = errors.Join(errs, errors.New("manifest metadata.name is required"))
+	}
+	return errs
+}
+
+// normalize converts v to its json representation
+func normalize(v any) (Manifest, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	m := Manifest{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,164 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+const roleYAML = `
+apiVersion: bindplane.observiq.com/v1
+kind: Role
+metadata:
+  name: viewer
+  labels:
+    team: ops
+spec:
+  permissions:
+    - read
+  limit: 5
+`
+
+const roleJSON = `{
+  "apiVersion": "bindplane.observiq.com/v1",
+  "kind": "Role",
+  "metadata": {"labels": {"team": "ops"}, "name": "viewer"},
+  "spec": {"limit": 5, "permissions": ["read"]}
+}`
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		expectErr string
+	}{
+		{"yaml", roleYAML, ""},
+		{"json", roleJSON, ""},
+		{"empty", "", "manifest is empty"},
+		{"invalid", "kind: [", "parse manifest"},
+		{"multiple documents", roleYAML + "---\n" + roleYAML, "manifest must contain a single resource"},
+		{"missing fields", "spec: {}", "manifest apiVersion is required\nmanifest kind is required\nmanifest metadata.name is required"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Parse(tc.input)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, model.Kind("Role"), m.Kind())
+			require.Equal(t, "viewer", m.Name())
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	yamlManifest, err := Parse(roleYAML)
+	require.NoError(t, err)
+	jsonManifest, err := Parse(roleJSON)
+	require.NoError(t, err)
+	require.True(t, Equal(yamlManifest, jsonManifest))
+
+	withID, err := Parse(`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"id": "01HZ", "version": 3, "name": "viewer", "labels": {"team": "ops"}}, "spec": {"limit": 5, "permissions": ["read"]}}`)
+	require.NoError(t, err)
+	require.True(t, Equal(yamlManifest, withID), "metadata managed by bindplane should be ignored")
+
+	changed, err := Parse(`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"name": "viewer", "labels": {"team": "ops"}}, "spec": {"limit": 6, "permissions": ["read"]}}`)
+	require.NoError(t, err)
+	require.False(t, Equal(yamlManifest, changed))
+}
+
+func TestContains(t *testing.T) {
+	m, err := Parse(roleYAML)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		read   string
+		expect bool
+	}{
+		{
+			"equal",
+			roleJSON,
+			true,
+		},
+		{
+			"defaulted fields",
+			`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"id": "01HZ", "name": "viewer", "labels": {"team": "ops"}}, "spec": {"limit": 5, "permissions": ["read"], "enabled": true}}`,
+			true,
+		},
+		{
+			"changed field",
+			`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"name": "viewer", "labels": {"team": "ops"}}, "spec": {"limit": 6, "permissions": ["read"]}}`,
+			false,
+		},
+		{
+			"changed list",
+			`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"name": "viewer", "labels": {"team": "ops"}}, "spec": {"limit": 5, "permissions": ["read", "write"]}}`,
+			false,
+		},
+		{
+			"removed label",
+			`{"apiVersion": "bindplane.observiq.com/v1", "kind": "Role", "metadata": {"name": "viewer"}, "spec": {"limit": 5, "permissions": ["read"]}}`,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			read, err := Parse(tc.read)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, Contains(read, m))
+		})
+	}
+}
+
+func TestFromAnyResource(t *testing.T) {
+	r := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: "bindplane.observiq.com/v1",
+			Kind:       model.Kind("Role"),
+			Metadata: model.Metadata{
+				ID:      "01HZ",
+				Name:    "viewer",
+				Version: 3,
+			},
+		},
+		Spec: map[string]any{"limit": 5},
+	}
+
+	m, err := FromAnyResource(r)
+	require.NoError(t, err)
+	require.Equal(t, Manifest{
+		"apiVersion": "bindplane.observiq.com/v1",
+		"kind":       "Role",
+		"metadata":   map[string]any{"name": "viewer"},
+		"spec":       map[string]any{"limit": float64(5)},
+	}, m)
+
+	parsed, err := Parse(m.String())
+	require.NoError(t, err)
+	require.True(t, Equal(m, parsed))
+
+	a, err := parsed.AnyResource()
+	require.NoError(t, err)
+	require.Equal(t, "viewer", a.Name())
+	require.Equal(t, model.Kind("Role"), a.Kind)
+}
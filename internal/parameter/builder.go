@@ -0,0 +1,87 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// FromMap returns a parameter for each key value pair, sorted by
+// name. The parameters are validated the same as StringToParameter.
+func FromMap(values map[string]any) ([]model.Parameter, error) {
+	parameters := []model.Parameter{}
+	for name, value := range values {
+		parameters = append(parameters, model.Parameter{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].Name < parameters[j].Name
+	})
+
+	if err := validateParameters(parameters); err != nil {
+		return nil, fmt.Errorf("parameter validation failed: %w", err)
+	}
+	return parameters, nil
+}
+
+// OTTLCondition returns the value of a condition parameter
+// defined by a raw OTTL expression and its OTTL context. The
+// expression is validated with ValidateOTTLCondition.
+func OTTLCondition(expression, context string) (map[string]any, error) {
+	if expression == "" {
+		return nil, errors.New("condition expression must not be empty")
+	}
+	if err := ValidateOTTLCondition(expression, context); err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+	condition := map[string]any{
+		"ottl": expression,
+	}
+	if context != "" {
+		condition["ottlContext"] = context
+	}
+	return condition, nil
+}
+
+// UICondition returns the value of a condition parameter defined by
+// a condition UI statement, such as {"operator": "==", "key": "env",
+// "match": "resource", "value": "prod"}. The statement is validated
+// with ValidateOTTLConditionStatement.
+func UICondition(statement map[string]any) (map[string]any, error) {
+	b, err := jsoniter.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal condition: %w", err)
+	}
+
+	var ui OTTLConditionStatement
+	if err := jsoniter.Unmarshal(b, &ui); err != nil {
+		return nil, fmt.Errorf("malformed condition structure: %w", err)
+	}
+
+	if err := ValidateOTTLConditionStatement(ui); err != nil {
+		return nil, fmt.Errorf("invalid condition UI: %w", err)
+	}
+
+	return map[string]any{
+		"ui": statement,
+	}, nil
+}
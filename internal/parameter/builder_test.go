@@ -0,0 +1,104 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameter
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMap(t *testing.T) {
+	parameters, err := FromMap(map[string]any{
+		"telemetry_types":     []any{"Logs"},
+		"collection_interval": 60,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []model.Parameter{
+		{Name: "collection_interval", Value: 60},
+		{Name: "telemetry_types", Value: []any{"Logs"}},
+	}, parameters)
+
+	// The parameters round trip through parameters_json
+	s, err := ParametersToString(parameters)
+	require.NoError(t, err)
+	require.Equal(t, `[{"name":"collection_interval","value":60},{"name":"telemetry_types","value":["Logs"]}]`, s)
+
+	parameters, err = FromMap(map[string]any{})
+	require.NoError(t, err)
+	require.Empty(t, parameters)
+
+	_, err = FromMap(map[string]any{
+		"condition": map[string]any{
+			"ui": map[string]any{"operator": "==", "match": "resource"},
+		},
+	})
+	require.ErrorContains(t, err, "statement with operator '==' must have a key")
+}
+
+func TestOTTLCondition(t *testing.T) {
+	condition, err := OTTLCondition(`attributes["env"] == "prod"`, "log")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"ottl":        `attributes["env"] == "prod"`,
+		"ottlContext": "log",
+	}, condition)
+
+	condition, err = OTTLCondition("true", "")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"ottl": "true"}, condition)
+
+	_, err = OTTLCondition("", "log")
+	require.EqualError(t, err, "condition expression must not be empty")
+
+	_, err = OTTLCondition(`attributes["env"] ==`, "log")
+	require.ErrorContains(t, err, `invalid condition: condition has invalid syntax: 1:21: unexpected token "<EOF>"`)
+
+	// The condition is accepted as a condition parameter
+	_, err = FromMap(map[string]any{"condition": condition})
+	require.NoError(t, err)
+}
+
+func TestUICondition(t *testing.T) {
+	statement := map[string]any{
+		"operator": "or",
+		"statements": []any{
+			map[string]any{"operator": "==", "match": "resource", "key": "env", "value": "prod"},
+			map[string]any{"operator": "==", "match": "resource", "key": "env", "value": "stage"},
+		},
+	}
+
+	condition, err := UICondition(statement)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"ui": statement}, condition)
+
+	_, err = FromMap(map[string]any{"condition": condition})
+	require.NoError(t, err)
+
+	_, err = UICondition(map[string]any{
+		"operator": "and",
+		"statements": []any{
+			map[string]any{"operator": "==", "match": "resource", "key": "env", "value": "prod"},
+		},
+	})
+	require.EqualError(t, err, "invalid condition UI: parent operator 'and' must not have only one child statement, found 1")
+
+	_, err = UICondition(map[string]any{"operator": "==", "key": "env"})
+	require.EqualError(t, err, "invalid condition UI: statement with operator '==' must have a match")
+
+	_, err = UICondition(map[string]any{"statements": "invalid"})
+	require.ErrorContains(t, err, "malformed condition structure")
+}
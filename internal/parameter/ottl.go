@@ -0,0 +1,123 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// ottlContexts are the OTTL contexts supported by conditions, and
+// a function which parses a condition with the context's parser.
+var ottlContexts = map[string]func(condition string) error{
+	"resource":  ottlConditionParser(ottlresource.NewParser),
+	"scope":     ottlConditionParser(ottlscope.NewParser),
+	"log":       ottlConditionParser(ottllog.NewParser),
+	"span":      ottlConditionParser(ottlspan.NewParser),
+	"spanevent": ottlConditionParser(ottlspanevent.NewParser),
+	"metric":    ottlConditionParser(ottlmetric.NewParser),
+	"datapoint": ottlConditionParser(ottldatapoint.NewParser),
+}
+
+// ottlConditionParser returns a function which parses a condition
+// with an OTTL context parser and the standard OTTL converters.
+func ottlConditionParser[K any](
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error),
+) func(condition string) error {
+	return func(condition string) error {
+		parser, err := newParser(ottlfuncs.StandardConverters[K](), component.TelemetrySettings{Logger: zap.NewNop()})
+		if err != nil {
+			return err
+		}
+		_, err = parser.ParseCondition(condition)
+		return err
+	}
+}
+
+// parseOTTLConditionSyntax parses a condition without a context. Any
+// path or enum is accepted, so only the syntax and converters are checked.
+func parseOTTLConditionSyntax(condition string) error {
+	parser, err := ottl.NewParser(
+		ottlfuncs.StandardConverters[any](),
+		anyOTTLPath,
+		component.TelemetrySettings{Logger: zap.NewNop()},
+		ottl.WithEnumParser[any](anyOTTLEnum),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseCondition(condition)
+	return err
+}
+
+// anyOTTLPath accepts every segment and key of a path
+func anyOTTLPath(path ottl.Path[any]) (ottl.GetSetter[any], error) {
+	for p := path; p != nil; p = p.Next() {
+		p.Keys()
+	}
+	return ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) { return nil, nil },
+		Setter: func(context.Context, any, any) error { return nil },
+	}, nil
+}
+
+// anyOTTLEnum accepts every enum
+func anyOTTLEnum(*ottl.EnumSymbol) (*ottl.Enum, error) {
+	enum := ottl.Enum(0)
+	return &enum, nil
+}
+
+// ValidateOTTLContext returns an error if context is
+// not an OTTL context, such as "log".
+func ValidateOTTLContext(context string) error {
+	if _, ok := ottlContexts[context]; ok {
+		return nil
+	}
+	names := []string{}
+	for name := range ottlContexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("invalid OTTL context '%s', must be one of %s", context, strings.Join(names, ", "))
+}
+
+// ValidateOTTLCondition parses an OTTL condition, such as
+// `attributes["env"] == "prod" and severity_number >= 9`, with the
+// collector's OTTL parser. When context is set, paths and enums are
+// validated by the context's parser. Otherwise only the syntax and
+// converters are validated.
+func ValidateOTTLCondition(condition, context string) error {
+	if context == "" {
+		return parseOTTLConditionSyntax(condition)
+	}
+	if err := ValidateOTTLContext(context); err != nil {
+		return err
+	}
+	return ottlContexts[context](condition)
+}
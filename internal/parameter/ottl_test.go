@@ -0,0 +1,202 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOTTLCondition(t *testing.T) {
+	cases := []struct {
+		name      string
+		condition string
+		context   string
+		expectErr string
+	}{
+		{
+			name:      "comparison",
+			condition: `attributes["env"] == "prod"`,
+			context:   "log",
+		},
+		{
+			name:      "grouped comparisons",
+			condition: `(resource.attributes["service"] == "example.link") or (resource.attributes["telemetry.type"] == "metric")`,
+		},
+		{
+			name:      "not and boolean",
+			condition: `not (severity_number >= 9 and body != nil) or true`,
+			context:   "log",
+		},
+		{
+			name:      "converter",
+			condition: `IsMatch(body, "^error.*") and Len(attributes) > 0`,
+			context:   "log",
+		},
+		{
+			name:      "converter with keys and named arguments",
+			condition: `ParseJSON(attributes["json"])["level"] == "error" and IsMatch(target = name, pattern = "^http")`,
+			context:   "span",
+		},
+		{
+			name:      "converter as a boolean",
+			condition: `IsString(body)`,
+			context:   "log",
+		},
+		{
+			name:      "enum",
+			condition: `kind == SPAN_KIND_SERVER`,
+			context:   "span",
+		},
+		{
+			name:      "math expression",
+			condition: `(end_time_unix_nano - start_time_unix_nano) / 1000000 > 250.5`,
+			context:   "span",
+		},
+		{
+			name:      "negative numbers",
+			condition: `value_int > -1 and value_double < -.5`,
+			context:   "datapoint",
+		},
+		{
+			name:      "bytes, lists, and maps",
+			condition: `trace_id == 0x0102 and Concat(["a", "b"], "") == "ab" and body != {"key": [1, 2]}`,
+			context:   "log",
+		},
+		{
+			name:      "escaped string",
+			condition: `body == "say \"hello\""`,
+			context:   "log",
+		},
+		{
+			name:      "parent context paths",
+			condition: `resource.attributes["host"] != nil and instrumentation_scope.name == "x" and metric.name == "cpu"`,
+			context:   "datapoint",
+		},
+		{
+			name:      "own context prefix",
+			condition: `log.body == "x" and log.attributes["a"] == "b"`,
+			context:   "log",
+		},
+		{
+			name:      "path key",
+			condition: `attributes[cache["key"]] == "x"`,
+			context:   "resource",
+		},
+		{
+			name:      "unknown context",
+			condition: `true`,
+			context:   "logs",
+			expectErr: "invalid OTTL context 'logs', must be one of datapoint, log, metric, resource, scope, span, spanevent",
+		},
+		{
+			name:      "missing value",
+			condition: `attributes["env"] == `,
+			expectErr: `condition has invalid syntax: 1:22: unexpected token "<EOF>"`,
+		},
+		{
+			name:      "missing operator",
+			condition: `attributes["env"] "prod"`,
+			expectErr: `condition has invalid syntax: 1:19: unexpected token "prod" (expected <opcomparison> Value)`,
+		},
+		{
+			name:      "unclosed group",
+			condition: `(body == "a" or body == "b"`,
+			expectErr: `condition has invalid syntax: 1:28: unexpected token "<EOF>" (expected ")")`,
+		},
+		{
+			name:      "trailing tokens",
+			condition: `body == "a" body == "b"`,
+			expectErr: `condition has invalid syntax: 1:13: unexpected token "body"`,
+		},
+		{
+			name:      "single equals",
+			condition: `body = "a"`,
+			expectErr: `condition has invalid syntax: 1:6: unexpected token "=" (expected <opcomparison> Value)`,
+		},
+		{
+			name:      "error after a group",
+			condition: `(a == 1) and b = 2`,
+			expectErr: `condition has invalid syntax: 1:16: unexpected token "="`,
+		},
+		{
+			name:      "unterminated string",
+			condition: `body == "a`,
+			expectErr: "condition has invalid syntax: 1:9: lexer: invalid input text",
+		},
+		{
+			name:      "unexpected character",
+			condition: `body == 'a'`,
+			expectErr: "condition has invalid syntax: 1:9: lexer: invalid input text",
+		},
+		{
+			name:      "editor",
+			condition: `set(attributes["a"], "b") == nil`,
+			expectErr: "converter names must start with an uppercase letter but got 'set'",
+		},
+		{
+			name:      "multiline",
+			condition: "body == \"a\" and\n  attributes[] == \"b\"",
+			expectErr: `condition has invalid syntax: 2:14: unexpected token "]"`,
+		},
+		{
+			name:      "unknown converter",
+			condition: `IsFoo(body)`,
+			expectErr: `undefined function "IsFoo"`,
+		},
+		{
+			name:      "path not supported by context",
+			condition: `body == "a" and severity_text == "ERROR"`,
+			context:   "span",
+			expectErr: `segment "body" from path "body" is not a valid path nor a valid OTTL keyword for the span context`,
+		},
+		{
+			name:      "parent path not supported",
+			condition: `resource.name == "a"`,
+			context:   "log",
+			expectErr: `segment "name" from path "resource.name" is not a valid path nor a valid OTTL keyword for the resource context`,
+		},
+		{
+			name:      "context without field",
+			condition: `resource == nil`,
+			context:   "log",
+			expectErr: `segment "resource" from path "resource" is not a valid path nor a valid OTTL keyword for the log context`,
+		},
+		{
+			name:      "child context path",
+			condition: `log.body == "a"`,
+			context:   "resource",
+			expectErr: `segment "log" from path "log.body" is not a valid path nor a valid OTTL keyword for the resource context`,
+		},
+		{
+			name:      "path error column",
+			condition: `name == "a" and value_int > 1`,
+			context:   "metric",
+			expectErr: `segment "value_int" from path "value_int" is not a valid path nor a valid OTTL keyword for the metric context`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateOTTLCondition(tc.condition, tc.context)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -52,6 +52,32 @@ func ParametersToString(p []model.Parameter) (string, error) {
 	return string(paramBytes), nil
 }
 
+// MergeSensitive returns the parameters returned by Bindplane with the
+// values of sensitive parameters replaced by the values previously saved
+// to state. Bindplane masks sensitive values, saving the masked value to
+// state would cause Terraform to attempt to update the value.
+func MergeSensitive(incoming, state []model.Parameter) []model.Parameter {
+	for i, incomingParam := range incoming {
+		if !incomingParam.Sensitive {
+			continue
+		}
+		for _, stateParam := range state {
+			if stateParam.Name == incoming[i].Name {
+				// Set the value to the value provided by the user in order to
+				// prevent terraform from attempting to update the value.
+				incoming[i].Value = stateParam.Value
+
+				// Preserve the sensitive value to whatever the user configured, which
+				// could be true, false, or nothing.
+				incoming[i].Sensitive = stateParam.Sensitive
+
+				break
+			}
+		}
+	}
+	return incoming
+}
+
 // validateParameters validates the parameters for certain parameter types
 func validateParameters(parameters []model.Parameter) error {
 	for i, param := range parameters {
@@ -74,12 +100,19 @@ func validateParametersForConditions(param model.Parameter, paramIndex int) erro
 	// - a string OTTL expression (including ""), or
 	// - an object containing ottl/ui fields.
 	//
-	// Terraform configs commonly set condition to "" to mean "no condition". Treat string
-	// and nil values as valid and skip UI validation.
+	// Terraform configs commonly set condition to "" to mean "no condition". Treat nil
+	// and empty values as valid. String expressions have no context, so only their
+	// syntax is validated.
 	if param.Value == nil {
 		return nil
 	}
-	if _, ok := param.Value.(string); ok {
+	if expression, ok := param.Value.(string); ok {
+		if expression == "" {
+			return nil
+		}
+		if err := ValidateOTTLCondition(expression, ""); err != nil {
+			return fmt.Errorf("parameter %d: invalid condition: %w", paramIndex, err)
+		}
 		return nil
 	}
 
@@ -93,6 +126,18 @@ func validateParametersForConditions(param model.Parameter, paramIndex int) erro
 		return fmt.Errorf("parameter %d: malformed condition structure: %w", paramIndex, err)
 	}
 
+	if condition.OTTLContext != "" {
+		if err := ValidateOTTLContext(condition.OTTLContext); err != nil {
+			return fmt.Errorf("parameter %d: invalid condition: %w", paramIndex, err)
+		}
+	}
+
+	if condition.OTTL != "" {
+		if err := ValidateOTTLCondition(condition.OTTL, condition.OTTLContext); err != nil {
+			return fmt.Errorf("parameter %d: invalid condition: %w", paramIndex, err)
+		}
+	}
+
 	if err := ValidateOTTLConditionStatement(condition.UI); err != nil {
 		return fmt.Errorf("parameter %d: invalid condition UI: %w", paramIndex, err)
 	}
@@ -382,6 +382,55 @@ func TestStringToParameter_WithConditions(t *testing.T) {
 			]`,
 			"",
 		},
+		{
+			"invalid-ottl-string",
+			`[
+				{
+					"name": "condition",
+					"value": "attributes[\"env\"] = \"prod\""
+				}
+			]`,
+			`parameter validation failed: parameter 0: invalid condition: condition has invalid syntax: 1:19: unexpected token "=" (expected <opcomparison> Value)`,
+		},
+		{
+			"invalid-ottl-context-path",
+			`[
+				{
+					"name": "condition",
+					"value": {
+						"ottl": "(body == \"prod\")",
+						"ottlContext": "resource"
+					}
+				}
+			]`,
+			`parameter validation failed: parameter 0: invalid condition: segment "body" from path "body" is not a valid path nor a valid OTTL keyword for the resource context`,
+		},
+		{
+			"invalid-ottl-context",
+			`[
+				{
+					"name": "condition",
+					"value": {
+						"ottl": "",
+						"ottlContext": "logs"
+					}
+				}
+			]`,
+			"parameter validation failed: parameter 0: invalid condition: invalid OTTL context 'logs'",
+		},
+		{
+			"valid-ottl-context",
+			`[
+				{
+					"name": "condition",
+					"value": {
+						"ottl": "(attributes[\"env\"] == \"prod\")",
+						"ottlContext": "resource"
+					}
+				}
+			]`,
+			"",
+		},
 	}
 
 	for _, tc := range cases {
@@ -396,3 +445,22 @@ func TestStringToParameter_WithConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeSensitive(t *testing.T) {
+	incoming := []model.Parameter{
+		{Name: "hostname", Value: "otlp.example.com"},
+		{Name: "api_key", Value: "(sensitive)", Sensitive: true},
+		{Name: "password", Value: "(sensitive)", Sensitive: true},
+	}
+
+	state := []model.Parameter{
+		{Name: "hostname", Value: "old.example.com"},
+		{Name: "api_key", Value: "secret"},
+	}
+
+	require.Equal(t, []model.Parameter{
+		{Name: "hostname", Value: "otlp.example.com"},
+		{Name: "api_key", Value: "secret"},
+		{Name: "password", Value: "(sensitive)", Sensitive: true},
+	}, MergeSensitive(incoming, state))
+}
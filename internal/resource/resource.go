@@ -29,12 +29,19 @@ import (
 //
 // rParameters and rProcessors can be nil.
 func AnyResourceV1(id, rName, rType string, rKind model.Kind, rParameters []model.Parameter, rProcessors []model.ResourceConfiguration) (model.AnyResource, error) {
-	procs := []map[string]string{}
+	procs := []map[string]any{}
 	for _, p := range rProcessors {
-		proc := map[string]string{}
+		proc := map[string]any{}
 
+		// Processors are referenced by name, or defined
+		// inline with a type and parameters.
 		if p.Name != "" {
 			proc["name"] = p.Name
+		} else {
+			proc["type"] = p.Type
+			if len(p.Parameters) > 0 {
+				proc["parameters"] = p.Parameters
+			}
 		}
 
 		procs = append(procs, proc)
@@ -99,6 +106,10 @@ func AnyResourceFromConfigurationV1(c *model.Configuration) model.AnyResource {
 		a.Spec["parameters"] = c.Spec.Parameters
 	}
 
+	if c.Spec.Raw != "" {
+		a.Spec["raw"] = c.Spec.Raw
+	}
+
 	return a
 }
 
@@ -128,6 +128,40 @@ func TestAnyResourceV1(t *testing.T) {
 	}
 }
 
+func TestAnyResourceV1InlineProcessors(t *testing.T) {
+	processors := []model.ResourceConfiguration{
+		{
+			Name: "filter-a",
+		},
+		{
+			ParameterizedSpec: model.ParameterizedSpec{
+				Type: "batch",
+				Parameters: []model.Parameter{
+					{Name: "send_batch_size", Value: 100},
+				},
+			},
+		},
+		{
+			ParameterizedSpec: model.ParameterizedSpec{
+				Type: "count_telemetry",
+			},
+		},
+	}
+
+	r, err := AnyResourceV1("tf-bundle", "my-bundle", "processor_bundle", model.KindProcessor, nil, processors)
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{
+		{"name": "filter-a"},
+		{
+			"type": "batch",
+			"parameters": []model.Parameter{
+				{Name: "send_batch_size", Value: 100},
+			},
+		},
+		{"type": "count_telemetry"},
+	}, r.Spec["processors"])
+}
+
 func TestAnyResourceFromConfigurationV1(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -288,6 +322,90 @@ func TestAnyResourceFromConfigurationV1(t *testing.T) {
 				},
 			},
 		},
+		{
+			"raw",
+			&model.Configuration{
+				ResourceMeta: model.ResourceMeta{
+					APIVersion: "bindplane.observiq.com/v1",
+					Kind:       model.KindConfiguration,
+				},
+				Spec: model.ConfigurationSpec{
+					ContentType: "text/yaml",
+					Raw:         "receivers:\n  nop:\n",
+				},
+			},
+			model.AnyResource{
+				ResourceMeta: model.ResourceMeta{
+					APIVersion: "bindplane.observiq.com/v1",
+					Kind:       model.KindConfiguration,
+				},
+				Spec: map[string]any{
+					"contentType": "text/yaml",
+					"selector": model.AgentSelector{
+						MatchLabels: nil,
+					},
+					"raw": "receivers:\n  nop:\n",
+				},
+			},
+		},
+		{
+			"inline-source",
+			&model.Configuration{
+				ResourceMeta: model.ResourceMeta{
+					APIVersion: "bindplane.observiq.com/v1",
+					Kind:       model.KindConfiguration,
+				},
+				Spec: model.ConfigurationSpec{
+					ContentType: "text/yaml",
+					Sources: []model.ResourceConfiguration{
+						{
+							ParameterizedSpec: model.ParameterizedSpec{
+								Type: "host",
+								Parameters: []model.Parameter{
+									{Name: "collection_interval", Value: 30},
+								},
+								Processors: []model.ResourceConfiguration{
+									{
+										ParameterizedSpec: model.ParameterizedSpec{
+											Type: "batch",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			model.AnyResource{
+				ResourceMeta: model.ResourceMeta{
+					APIVersion: "bindplane.observiq.com/v1",
+					Kind:       model.KindConfiguration,
+				},
+				Spec: map[string]any{
+					"contentType": "text/yaml",
+					"selector": model.AgentSelector{
+						MatchLabels: nil,
+					},
+					"sources": []model.ResourceConfiguration{
+						{
+							ParameterizedSpec: model.ParameterizedSpec{
+								Type: "host",
+								Parameters: []model.Parameter{
+									{Name: "collection_interval", Value: 30},
+								},
+								Processors: []model.ResourceConfiguration{
+									{
+										ParameterizedSpec: model.ParameterizedSpec{
+											Type: "batch",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,257 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcetype provides functions for defining Bindplane
+// resource types, such as custom source and processor types.
+package resourcetype
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+)
+
+// TelemetryTypes are the telemetry types a resource type can
+// define templates for.
+var TelemetryTypes = []string{"logs", "metrics", "traces"}
+
+// Platforms are the platforms a resource type can support
+var Platforms = []string{
+	"linux",
+	"windows",
+	"macos",
+	"kubernetes-daemonset",
+	"kubernetes-deployment",
+	"kubernetes-gateway",
+	"openshift-daemonset",
+	"openshift-deployment",
+}
+
+// Template is the OpenTelemetry configuration rendered by a
+// resource type for a single telemetry type. Each field is a
+// Go template which renders a YAML list of components.
+type Template struct {
+	Receivers  string `json:"receivers,omitempty"`
+	Processors string `json:"processors,omitempty"`
+	Exporters  string `json:"exporters,omitempty"`
+	Extensions string `json:"extensions,omitempty"`
+	Connectors string `json:"connectors,omitempty"`
+}
+
+// ResourceType is a Bindplane resource type
+type ResourceType struct {
+	Kind        model.Kind
+	Name        string
+	DisplayName string
+	Description string
+
+	// Parameters are the parameter definitions in
+	// their json representation.
+	Parameters []map[string]any
+
+	SupportedPlatforms []string
+	TelemetryTypes     []string
+
+	// Templates are keyed by telemetry type
+	Templates map[string]Template
+}
+
+// AnyResource returns the resource type as a
+// bindplane.observiq.com/v1.AnyResource.
+func (r *ResourceType) AnyResource() (model.AnyResource, error) {
+	switch r.Kind {
+	case model.KindSourceType, model.KindDestinationType, model.KindProcessorType, model.KindExtensionType, model.KindConnectorType:
+	default:
+		return model.AnyResource{}, fmt.Errorf("unknown resource type kind: %s", r.Kind)
+	}
+
+	spec := map[string]any{}
+
+	if len(r.Parameters) > 0 {
+		spec["parameters"] = r.Parameters
+	}
+
+	if len(r.SupportedPlatforms) > 0 {
+		spec["supportedPlatforms"] = r.SupportedPlatforms
+	}
+
+	if len(r.TelemetryTypes) > 0 {
+		spec["telemetryTypes"] = r.TelemetryTypes
+	}
+
+	for telemetryType, template := range r.Templates {
+		spec[telemetryType] = template
+	}
+
+	return model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: "bindplane.observiq.com/v1",
+			Kind:       r.Kind,
+			Metadata: model.Metadata{
+				Name:        r.Name,
+				DisplayName: r.DisplayName,
+				Description: r.Description,
+			},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// FromAnyResource returns the resource type read from Bindplane
+func FromAnyResource(a *model.AnyResource) (*ResourceType, error) {
+	r := &ResourceType{
+		Kind:        a.Kind,
+		Name:        a.Name(),
+		DisplayName: a.Metadata.DisplayName,
+		Description: a.Metadata.Description,
+		Templates:   map[string]Template{},
+	}
+
+	// The spec is decoded by way of its json representation
+	// so that typed and untyped specs are handled the same.
+	b, err := json.Marshal(a.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s '%s' spec: %w", a.Kind, a.Name(), err)
+	}
+
+	spec := struct {
+		Parameters         []map[string]any `json:"parameters"`
+		SupportedPlatforms []string         `json:"supportedPlatforms"`
+		TelemetryTypes     []string         `json:"telemetryTypes"`
+		Logs               *Template        `json:"logs"`
+		Metrics            *Template        `json:"metrics"`
+		Traces             *Template        `json:"traces"`
+	}{}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("unmarshal %s '%s' spec: %w", a.Kind, a.Name(), err)
+	}
+
+	r.Parameters = spec.Parameters
+	r.SupportedPlatforms = spec.SupportedPlatforms
+	r.TelemetryTypes = spec.TelemetryTypes
+
+	for telemetryType, template := range map[string]*Template{
+		"logs":    spec.Logs,
+		"metrics": spec.Metrics,
+		"traces":  spec.Traces,
+	} {
+		if template != nil && *template != (Template{}) {
+			r.Templates[telemetryType] = *template
+		}
+	}
+
+	return r, nil
+}
+
+// ParseParameters unmarshals serialized json parameter definitions.
+// Each parameter definition requires a name and type.
+func ParseParameters(s string) ([]map[string]any, error) {
+	parameters := []map[string]any{}
+	if s == "" {
+		return parameters, nil
+	}
+
+	if err := json.Unmarshal([]byte(s), &parameters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameter definitions: %w", err)
+	}
+
+	var errs error
+	for i, p := range parameters {
+		if name, _ := p["name"].(string); name == "" {
+			errs = errors.Join(errs, fmt.Errorf("parameter definition %d: name is required", i))
+		}
+		if t, _ := p["type"].(string); t == "" {
+			errs = errors.Join(errs, fmt.Errorf("parameter definition %d: type is required", i))
+		}
+	}
+	if errs != nil {
+		return nil, fmt.Errorf("parameter definition validation failed: %w", errs)
+	}
+
+	return parameters, nil
+}
+
+// ParametersToString converts parameter definitions to serialized json
+func ParametersToString(parameters []map[string]any) (string, error) {
+	if len(parameters) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal parameter definitions: %w", err)
+	}
+	return string(b), nil
+}
+
+// ParameterDefinition is a resource type parameter definition
+type ParameterDefinition struct {
+	Name        string
+	Label       string
+	Description string
+	Type        string
+
+	// Default is the default value, nil if unset
+	Default any
+
+	Required bool
+
+	// ValidValues are the valid values of enum parameters
+	ValidValues []string
+
+	// RelevantIf are the conditions under which the parameter
+	// is relevant, nil if the parameter is always relevant.
+	RelevantIf any
+
+	Sensitive      bool
+	AdvancedConfig bool
+}
+
+// ParameterDefinitions converts parameter definitions in their
+// json representation to ParameterDefinitions.
+func ParameterDefinitions(parameters []map[string]any) []ParameterDefinition {
+	definitions := make([]ParameterDefinition, 0, len(parameters))
+	for _, p := range parameters {
+		def := ParameterDefinition{
+			Default:    p["default"],
+			RelevantIf: p["relevantIf"],
+		}
+		def.Name, _ = p["name"].(string)
+		def.Label, _ = p["label"].(string)
+		def.Description, _ = p["description"].(string)
+		def.Type, _ = p["type"].(string)
+		def.Required, _ = p["required"].(bool)
+		def.AdvancedConfig, _ = p["advancedConfig"].(bool)
+
+		// Sensitivity is reported in the parameter's options by
+		// current Bindplane versions, and at the top level by others.
+		def.Sensitive, _ = p["sensitive"].(bool)
+		if options, ok := p["options"].(map[string]any); ok {
+			if sensitive, _ := options["sensitive"].(bool); sensitive {
+				def.Sensitive = true
+			}
+		}
+
+		if values, ok := p["validValues"].([]any); ok {
+			for _, v := range values {
+				def.ValidValues = append(def.ValidValues, fmt.Sprint(v))
+			}
+		}
+
+		definitions = append(definitions, def)
+	}
+	return definitions
+}
@@ -0,0 +1,164 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcetype
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceTypeAnyResource(t *testing.T) {
+	rt := &ResourceType{
+		Kind:        model.KindSourceType,
+		Name:        "custom_journald",
+		DisplayName: "Custom Journald",
+		Parameters: []map[string]any{
+			{"name": "units", "type": "strings"},
+		},
+		SupportedPlatforms: []string{"linux"},
+		TelemetryTypes:     []string{"logs"},
+		Templates: map[string]Template{
+			"logs": {Receivers: "- journald:\n    units: {{ .units }}\n"},
+		},
+	}
+
+	a, err := rt.AnyResource()
+	require.NoError(t, err)
+	require.Equal(t, model.KindSourceType, a.Kind)
+	require.Equal(t, "custom_journald", a.Metadata.Name)
+	require.Equal(t, "Custom Journald", a.Metadata.DisplayName)
+	require.Equal(t, []string{"linux"}, a.Spec["supportedPlatforms"])
+	require.NotContains(t, a.Spec, "metrics")
+
+	// The resource type should survive a round trip
+	out, err := FromAnyResource(&a)
+	require.NoError(t, err)
+	require.Equal(t, rt, out)
+
+	_, err = (&ResourceType{Kind: model.KindSource}).AnyResource()
+	require.EqualError(t, err, "unknown resource type kind: Source")
+}
+
+func TestFromAnyResource(t *testing.T) {
+	a := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			Kind:     model.KindProcessorType,
+			Metadata: model.Metadata{ID: "01HZ", Name: "custom_filter"},
+		},
+		Spec: map[string]any{
+			"version":    "0.0.1",
+			"parameters": []any{map[string]any{"name": "expr", "type": "string", "default": ""}},
+			"logs":       map[string]any{"processors": "- filter: {}\n"},
+			"metrics":    map[string]any{},
+		},
+	}
+
+	rt, err := FromAnyResource(a)
+	require.NoError(t, err)
+	require.Equal(t, &ResourceType{
+		Kind:       model.KindProcessorType,
+		Name:       "custom_filter",
+		Parameters: []map[string]any{{"name": "expr", "type": "string", "default": ""}},
+		Templates: map[string]Template{
+			"logs": {Processors: "- filter: {}\n"},
+		},
+	}, rt)
+}
+
+func TestParseParameters(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		expect    []map[string]any
+		expectErr string
+	}{
+		{
+			"empty",
+			"",
+			[]map[string]any{},
+			"",
+		},
+		{
+			"valid",
+			`[{"name": "port", "type": "int", "default": 514}]`,
+			[]map[string]any{{"name": "port", "type": "int", "default": float64(514)}},
+			"",
+		},
+		{
+			"invalid json",
+			`{"name": "port"}`,
+			nil,
+			"failed to unmarshal parameter definitions",
+		},
+		{
+			"missing fields",
+			`[{"label": "Port"}]`,
+			nil,
+			"parameter definition validation failed: parameter definition 0: name is required\nparameter definition 0: type is required",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := ParseParameters(tc.input)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, out)
+
+			s, err := ParametersToString(out)
+			require.NoError(t, err)
+			if len(out) == 0 {
+				require.Equal(t, "", s)
+			}
+		})
+	}
+}
+
+func TestParameterDefinitions(t *testing.T) {
+	parameters, err := ParseParameters(`[
+		{"name": "protocol", "label": "Protocol", "type": "enum", "default": "grpc", "validValues": ["grpc", "http"], "required": true},
+		{"name": "api_key", "type": "string", "options": {"sensitive": true}, "advancedConfig": true},
+		{"name": "path", "type": "string", "sensitive": true, "relevantIf": [{"name": "protocol", "operator": "equals", "value": "http"}]}
+	]`)
+	require.NoError(t, err)
+
+	require.Equal(t, []ParameterDefinition{
+		{
+			Name:        "protocol",
+			Label:       "Protocol",
+			Type:        "enum",
+			Default:     "grpc",
+			Required:    true,
+			ValidValues: []string{"grpc", "http"},
+		},
+		{
+			Name:           "api_key",
+			Type:           "string",
+			Sensitive:      true,
+			AdvancedConfig: true,
+		},
+		{
+			Name:       "path",
+			Type:       "string",
+			Sensitive:  true,
+			RelevantIf: []any{map[string]any{"name": "protocol", "operator": "equals", "value": "http"}},
+		},
+	}, ParameterDefinitions(parameters))
+}
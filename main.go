@@ -16,12 +16,23 @@
 package main
 
 import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/observiq/terraform-provider-bindplane/provider"
 )
 
 func main() {
+	server, err := provider.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
+		GRPCProviderFunc: func() tfprotov5.ProviderServer {
+			return server
+		},
 	})
 }
@@ -0,0 +1,118 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+var currentVersionSchema = &schema.Schema{
+	Type:        schema.TypeInt,
+	Computed:    true,
+	Description: "The configuration version rolled out to agents. Zero if no version has been rolled out.",
+}
+
+var pendingVersionSchema = &schema.Schema{
+	Type:        schema.TypeInt,
+	Computed:    true,
+	Description: "The configuration version being rolled out to agents. Zero if no rollout is in progress.",
+}
+
+// configurationCustomizeDiff returns the CustomizeDiff function
+// of configuration resources.
+func configurationCustomizeDiff(rType string) schema.CustomizeDiffFunc {
+	return customdiff.All(
+		resourceCustomizeDiff(rType),
+		customizeDiffRollback,
+		customizeDiffConfigurationVersions,
+		customizeDiffSelector,
+	)
+}
+
+// customizeDiffRollback returns an error when the configuration's latest
+// version was created by a bindplane_configuration_rollback and the plan
+// would change the configuration, undoing the rollback. Removing the
+// rollback label is allowed, it is the only change once the configuration
+// matches the restored version. The check is skipped when last_writer_wins
+// is enabled.
+func customizeDiffRollback(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	bindplane, ok := meta.(*client.BindPlane)
+	if !ok || bindplane.LastWriterWins || d.Id() == "" {
+		return nil
+	}
+	if !changesOtherThanLabels(d.GetChangedKeysPrefix("")) {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return nil
+	}
+
+	if restored := client.RollbackVersion(config); restored != 0 {
+		return fmt.Errorf(
+			"configuration '%s' version %d was rolled back to version %d, applying this change would undo the rollback. "+
+				"Update the configuration to match version %d, or set the provider option last_writer_wins to overwrite it",
+			name, config.Version(), restored, restored)
+	}
+	return nil
+}
+
+// changesOtherThanLabels returns true if any of the changed keys
+// are not the configuration's labels.
+func changesOtherThanLabels(keys []string) bool {
+	for _, key := range keys {
+		if key != "labels" && !strings.HasPrefix(key, "labels.") {
+			return true
+		}
+	}
+	return false
+}
+
+// customizeDiffConfigurationVersions marks current_version and
+// pending_version as unknown when the configuration will be updated,
+// because updates create a new version which may be rolled out.
+func customizeDiffConfigurationVersions(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if err := d.SetNewComputed("current_version"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("pending_version")
+}
+
+// setConfigurationVersions saves the current and pending
+// versions of a configuration to state.
+func setConfigurationVersions(d *schema.ResourceData, config *model.Configuration) error {
+	if err := d.Set("current_version", int(config.Status.CurrentVersion)); err != nil {
+		return err
+	}
+	return d.Set("pending_version", int(config.Status.PendingVersion))
+}
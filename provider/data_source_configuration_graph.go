@@ -0,0 +1,86 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+)
+
+func dataSourceConfigurationGraph() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConfigurationGraphRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the configuration.",
+			},
+			"telemetry_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					telemetryType := val.(string)
+					if err := component.ValidateRouteType(telemetryType); err != nil {
+						errs = append(errs, err)
+					}
+					return
+				},
+				Description: "Only include routes of this telemetry type. Valid types include 'logs', 'metrics', or 'traces'. Defaults to all telemetry types.",
+			},
+			"dot": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration's topology as a Graphviz DOT digraph.",
+			},
+			"mermaid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration's topology as a Mermaid flowchart.",
+			},
+		},
+	}
+}
+
+func dataSourceConfigurationGraphRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	name := d.Get("name").(string)
+	telemetryType := d.Get("telemetry_type").(string)
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("configuration %s does not exist", name)
+	}
+
+	topology := component.ConfigurationTopology(config, telemetryType)
+	dot := topology.DOT()
+
+	if err := d.Set("dot", dot); err != nil {
+		return err
+	}
+	if err := d.Set("mermaid", topology.Mermaid()); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", name, renderedHash(dot)))
+	return nil
+}
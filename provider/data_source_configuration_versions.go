@@ -0,0 +1,122 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+func dataSourceConfigurationVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceConfigurationVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the configuration.",
+			},
+			"current_version": currentVersionSchema,
+			"pending_version": pendingVersionSchema,
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"updated_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user who created the version, if reported by Bindplane.",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the version was created, if reported by Bindplane.",
+						},
+						"rollout_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the version's rollout, such as 'started', 'paused', 'error', or 'stable'. Empty if the version was never rolled out.",
+						},
+						"current": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the version is rolled out to agents.",
+						},
+						"pending": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the version is being rolled out to agents.",
+						},
+						"latest": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the version is the most recent version.",
+						},
+					},
+				},
+				Description: "The configuration's versions, newest first.",
+			},
+		},
+	}
+}
+
+func dataSourceConfigurationVersionsRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+	name := d.Get("name").(string)
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("configuration %s does not exist", name)
+	}
+
+	if err := setConfigurationVersions(d, config); err != nil {
+		return err
+	}
+
+	versions, err := bindplane.ConfigurationVersions(name)
+	if err != nil {
+		return err
+	}
+
+	versionBlocks := []map[string]any{}
+	for _, v := range versions {
+		versionBlocks = append(versionBlocks, map[string]any{
+			"version":        int(v.Version),
+			"updated_by":     v.UpdatedBy,
+			"updated_at":     v.UpdatedAt,
+			"rollout_status": v.RolloutStatus,
+			"current":        v.Current,
+			"pending":        v.Pending,
+			"latest":         v.Latest,
+		})
+	}
+	if err := d.Set("versions", versionBlocks); err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return nil
+}
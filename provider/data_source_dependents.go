@@ -0,0 +1,172 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/dependents"
+)
+
+// dependentKinds maps the data source's kind option
+// to Bindplane resource kinds.
+var dependentKinds = map[string]model.Kind{
+	"source":      model.KindSource,
+	"destination": model.KindDestination,
+	"processor":   model.KindProcessor,
+	"extension":   model.KindExtension,
+	"connector":   model.KindConnector,
+}
+
+func dataSourceDependents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDependentsRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					kind := val.(string)
+					if _, ok := dependentKinds[kind]; !ok {
+						errs = append(errs, fmt.Errorf("invalid kind: %s, must be one of source, destination, processor, extension, or connector", kind))
+					}
+					return
+				},
+				Description: "The kind of component to find dependents for. Valid values are 'source', 'destination', 'processor', 'extension', and 'connector'.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the component to find dependents for.",
+			},
+			"configurations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the configurations which reference the component.",
+			},
+			"processor_bundles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the processor bundles which reference the component.",
+			},
+			"reference": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of the referencing resource, 'Configuration' or 'Processor'.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the referencing resource.",
+						},
+						"location": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the component is referenced. One of 'source', 'source_processor', 'destination', 'destination_processor', 'processor_group', 'connector', 'extension', 'route', or 'processor_bundle'.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path to the reference within the referencing resource's spec, such as 'sources[0].processors[1]'.",
+						},
+						"route_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The route ID of the reference, if it has one.",
+						},
+					},
+				},
+				Description: "Every location the component is referenced from.",
+			},
+		},
+	}
+}
+
+func dataSourceDependentsRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	kindName := d.Get("kind").(string)
+	kind := dependentKinds[kindName]
+	name := d.Get("name").(string)
+
+	configs, err := bindplane.Configurations()
+	if err != nil {
+		return err
+	}
+
+	refs := []dependents.Reference{}
+	configNames := []string{}
+	for _, c := range configs {
+		r := dependents.InConfiguration(c, kind, name)
+		if len(r) == 0 {
+			continue
+		}
+		configNames = append(configNames, c.Name())
+		refs = append(refs, r...)
+	}
+
+	// Only processors can be referenced by processor bundles
+	bundleNames := []string{}
+	if kind == model.KindProcessor {
+		processors, err := bindplane.Processors()
+		if err != nil {
+			return err
+		}
+
+		for _, p := range processors {
+			r := dependents.InProcessorBundle(p, name)
+			if len(r) == 0 {
+				continue
+			}
+			bundleNames = append(bundleNames, p.Name())
+			refs = append(refs, r...)
+		}
+	}
+
+	if err := d.Set("configurations", configNames); err != nil {
+		return err
+	}
+
+	if err := d.Set("processor_bundles", bundleNames); err != nil {
+		return err
+	}
+
+	refBlocks := []map[string]any{}
+	for _, r := range refs {
+		refBlocks = append(refBlocks, map[string]any{
+			"kind":     string(r.Kind),
+			"name":     r.Name,
+			"location": r.Location,
+			"path":     r.Path,
+			"route_id": r.RouteID,
+		})
+	}
+	if err := d.Set("reference", refBlocks); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", kindName, name))
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+)
+
+func dataSourceRenderedConfiguration() *schema.Resource {
+	componentIDs := func(kind string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("IDs of the %s defined by the rendered configuration, sorted.", kind),
+		}
+	}
+
+	return &schema.Resource{
+		Read: dataSourceRenderedConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the configuration to render.",
+			},
+			"agent_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"platform"},
+				Description:   "Render the configuration as it would be sent to the agent with this ID.",
+			},
+			"platform": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"agent_id"},
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					platform := val.(string)
+					if !isValidPlatform(platform) {
+						errs = append(errs, fmt.Errorf("invalid platform: %s", platform))
+					}
+					return
+				},
+				Description: "Render the configuration for this platform. Defaults to the configuration's platform.",
+			},
+			"otel_yaml": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered OpenTelemetry collector configuration YAML.",
+			},
+			"hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 hash of otel_yaml, hex encoded.",
+			},
+			"receivers":  componentIDs("receivers"),
+			"processors": componentIDs("processors"),
+			"exporters":  componentIDs("exporters"),
+			"connectors": componentIDs("connectors"),
+			"extensions": componentIDs("extensions"),
+		},
+	}
+}
+
+func dataSourceRenderedConfigurationRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	name := d.Get("name").(string)
+	agentID := d.Get("agent_id").(string)
+	platform := d.Get("platform").(string)
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("configuration %s does not exist", name)
+	}
+
+	raw, err := bindplane.RenderedConfiguration(name, agentID, platform)
+	if err != nil {
+		return err
+	}
+
+	ids, err := configuration.RawComponents(raw)
+	if err != nil {
+		return fmt.Errorf("rendered configuration %s: %w", name, err)
+	}
+
+	hash := renderedHash(raw)
+
+	values := map[string]any{
+		"otel_yaml":  raw,
+		"hash":       hash,
+		"receivers":  ids.Receivers,
+		"processors": ids.Processors,
+		"exporters":  ids.Exporters,
+		"connectors": ids.Connectors,
+		"extensions": ids.Extensions,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", name, hash))
+	return nil
+}
+
+// renderedHash returns the hex encoded SHA-256 hash of a rendered configuration
+func renderedHash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
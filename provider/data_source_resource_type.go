@@ -0,0 +1,241 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/resourcetype"
+)
+
+// resourceTypeKinds maps the data source's kind option
+// to Bindplane resource type kinds.
+var resourceTypeKinds = map[string]model.Kind{
+	"source":      model.KindSourceType,
+	"destination": model.KindDestinationType,
+	"processor":   model.KindProcessorType,
+	"extension":   model.KindExtensionType,
+	"connector":   model.KindConnectorType,
+}
+
+func dataSourceResourceType() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceResourceTypeRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					kind := val.(string)
+					if _, ok := resourceTypeKinds[kind]; !ok {
+						errs = append(errs, fmt.Errorf("invalid kind: %s, must be one of source, destination, processor, extension, or connector", kind))
+					}
+					return
+				},
+				Description: "The kind of component the type is for. Valid values are 'source', 'destination', 'processor', 'extension', and 'connector'.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the resource type, such as 'otlp_grpc'.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource type's display name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource type's description.",
+			},
+			"supported_platforms": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The agent platforms the resource type supports.",
+			},
+			"telemetry_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The telemetry types the resource type supports.",
+			},
+			"parameters_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full JSON list of parameter definitions.",
+			},
+			"parameter": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter name, used in parameters_json of components.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter's label.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter's description.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parameter's type, such as 'string', 'int', 'bool', 'enum', or 'strings'.",
+						},
+						"default_json": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded default value. Empty if the parameter has no default.",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not the parameter is required.",
+						},
+						"valid_values": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The valid values of enum parameters.",
+						},
+						"relevant_if_json": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded conditions under which the parameter is relevant. Empty if the parameter is always relevant.",
+						},
+						"sensitive": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not the parameter's value is sensitive.",
+						},
+						"advanced": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not the parameter is an advanced option.",
+						},
+					},
+				},
+				Description: "The resource type's parameter definitions.",
+			},
+		},
+	}
+}
+
+func dataSourceResourceTypeRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	kindName := d.Get("kind").(string)
+	rKind := resourceTypeKinds[kindName]
+	name := d.Get("name").(string)
+
+	a, err := bindplane.AnyResource(rKind, name)
+	if err != nil {
+		return err
+	}
+
+	// bindplane.AnyResource will return a nil error if the resource
+	// does not exist. It is up to the caller to check.
+	if a == nil {
+		return fmt.Errorf("%s with name '%s' does not exist", rKind, name)
+	}
+
+	rt, err := resourcetype.FromAnyResource(a)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("display_name", rt.DisplayName); err != nil {
+		return err
+	}
+
+	if err := d.Set("description", rt.Description); err != nil {
+		return err
+	}
+
+	if err := d.Set("supported_platforms", rt.SupportedPlatforms); err != nil {
+		return err
+	}
+
+	if err := d.Set("telemetry_types", rt.TelemetryTypes); err != nil {
+		return err
+	}
+
+	parametersJSON, err := resourcetype.ParametersToString(rt.Parameters)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("parameters_json", parametersJSON); err != nil {
+		return err
+	}
+
+	parameterBlocks := []map[string]any{}
+	for _, p := range resourcetype.ParameterDefinitions(rt.Parameters) {
+		defaultJSON, err := optionalJSON(p.Default)
+		if err != nil {
+			return fmt.Errorf("parameter %s default: %w", p.Name, err)
+		}
+
+		relevantIfJSON, err := optionalJSON(p.RelevantIf)
+		if err != nil {
+			return fmt.Errorf("parameter %s relevantIf: %w", p.Name, err)
+		}
+
+		parameterBlocks = append(parameterBlocks, map[string]any{
+			"name":             p.Name,
+			"label":            p.Label,
+			"description":      p.Description,
+			"type":             p.Type,
+			"default_json":     defaultJSON,
+			"required":         p.Required,
+			"valid_values":     p.ValidValues,
+			"relevant_if_json": relevantIfJSON,
+			"sensitive":        p.Sensitive,
+			"advanced":         p.AdvancedConfig,
+		})
+	}
+	if err := d.Set("parameter", parameterBlocks); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", kindName, name))
+	return nil
+}
+
+// optionalJSON returns the json encoding of v,
+// or an empty string if v is nil.
+func optionalJSON(v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,43 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  any
+		expect string
+	}{
+		{"nil", nil, ""},
+		{"string", "grpc", `"grpc"`},
+		{"number", float64(4317), "4317"},
+		{"false", false, "false"},
+		{"list", []any{"a", "b"}, `["a","b"]`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := optionalJSON(tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
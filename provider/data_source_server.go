@@ -0,0 +1,59 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+func dataSourceServer() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServerRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Bindplane server.",
+			},
+			"features": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+				Description: "Provider features which require a minimum Bindplane version, and whether or not the Bindplane server supports them.",
+			},
+		},
+	}
+}
+
+func dataSourceServerRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	server, err := bindplane.ServerVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("version", server.String()); err != nil {
+		return err
+	}
+
+	if err := d.Set("features", supportedFeatures(server)); err != nil {
+		return err
+	}
+
+	d.SetId(server.String())
+	return nil
+}
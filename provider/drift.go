@@ -0,0 +1,132 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+// readWithDriftWarnings wraps a resource read function. When the version
+// read from Bindplane differs from the version Terraform last saved to state,
+// and attributes managed by Terraform changed as a result, a warning is
+// returned describing who changed the resource, when, and which attributes
+// changed. This makes changes made outside of Terraform visible in plan
+// output.
+//
+// Create and update functions call the read function directly, so changes
+// made by Terraform itself do not produce a warning.
+func readWithDriftWarnings(rKind model.Kind, read schema.ReadFunc) schema.ReadContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		prevVersion, _ := d.Get("version").(int)
+		prevAttributes := stateAttributes(d)
+
+		if err := read(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Resources which were removed, imported, or have not
+		// been read before are not checked.
+		if d.Id() == "" || prevVersion == 0 {
+			return nil
+		}
+
+		version, _ := d.Get("version").(int)
+		if version == prevVersion {
+			return nil
+		}
+
+		// Bindplane creates new versions of configurations when
+		// their components are updated. These versions do not
+		// change any attributes managed by Terraform.
+		changed := changedAttributes(prevAttributes, stateAttributes(d))
+		if len(changed) == 0 {
+			return nil
+		}
+
+		bindplane := meta.(*client.BindPlane)
+		name := d.Get("name").(string)
+
+		// The warning is still useful without the modification
+		// details, so errors are not returned.
+		by, at := "an unknown user", "an unknown time"
+		if m, err := bindplane.LastModification(rKind, name); err == nil && m != nil {
+			if m.UpdatedBy != "" {
+				by = m.UpdatedBy
+			}
+			if m.UpdatedAt != "" {
+				at = m.UpdatedAt
+			}
+		}
+
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s %q was modified outside of Terraform", rKind, name),
+				Detail: fmt.Sprintf(
+					"%s %q was changed from version %d to version %d by %s at %s. The following attributes changed: %s. "+
+						"Applying will overwrite these changes with the Terraform configuration.",
+					rKind, name, prevVersion, version, by, at, strings.Join(changed, ", ")),
+			},
+		}
+	}
+}
+
+// stateAttributes returns the flattened attributes of the resource data.
+// Returns nil if the resource data has no state.
+func stateAttributes(d *schema.ResourceData) map[string]string {
+	s := d.State()
+	if s == nil {
+		return nil
+	}
+	return s.Attributes
+}
+
+// changedAttributes compares two sets of flattened attributes and returns
+// the sorted top level attribute names which differ. The id and version
+// attributes are ignored.
+func changedAttributes(prev, next map[string]string) []string {
+	changed := map[string]struct{}{}
+
+	compare := func(a, b map[string]string) {
+		for k, v := range a {
+			if other, ok := b[k]; ok && other == v {
+				continue
+			}
+			name := strings.SplitN(k, ".", 2)[0]
+			switch name {
+			case "id", "version":
+				continue
+			}
+			changed[name] = struct{}{}
+		}
+	}
+	compare(prev, next)
+	compare(next, prev)
+
+	names := make([]string, 0, len(changed))
+	for name := range changed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
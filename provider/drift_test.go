@@ -0,0 +1,67 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedAttributes(t *testing.T) {
+	cases := []struct {
+		name   string
+		prev   map[string]string
+		next   map[string]string
+		expect []string
+	}{
+		{
+			"unchanged",
+			map[string]string{"id": "a", "name": "otlp", "version": "1"},
+			map[string]string{"id": "a", "name": "otlp", "version": "2"},
+			[]string{},
+		},
+		{
+			"changed value",
+			map[string]string{"name": "otlp", "parameters_json": `[{"name":"a","value":1}]`},
+			map[string]string{"name": "otlp", "parameters_json": `[{"name":"a","value":2}]`},
+			[]string{"parameters_json"},
+		},
+		{
+			"nested attributes",
+			map[string]string{"source.#": "1", "source.0.name": "otlp", "labels.%": "0"},
+			map[string]string{"source.#": "2", "source.0.name": "otlp", "source.1.name": "host", "labels.%": "1", "labels.env": "prod"},
+			[]string{"labels", "source"},
+		},
+		{
+			"removed attribute",
+			map[string]string{"name": "otlp", "measurement_interval": "1m"},
+			map[string]string{"name": "otlp"},
+			[]string{"measurement_interval"},
+		},
+		{
+			"nil previous state",
+			nil,
+			map[string]string{"id": "a"},
+			[]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, changedAttributes(tc.prev, tc.next))
+		})
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/observiq/terraform-provider-bindplane/internal/parameter"
+)
+
+// functionProvider is a plugin framework provider which defines the
+// provider's functions. The plugin SDK does not support functions, so
+// they are served alongside the SDK provider's resources and data sources.
+type functionProvider struct{}
+
+var _ fwprovider.ProviderWithFunctions = &functionProvider{}
+
+// Metadata returns the provider's type name
+func (p *functionProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "bindplane"
+}
+
+// Schema returns the SDK provider's schema. Providers served
+// together must have the same schema.
+func (p *functionProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	s, err := frameworkProviderSchema(Configure().Schema)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider schema", err.Error())
+		return
+	}
+	resp.Schema = s
+}
+
+// Configure does nothing, functions do not connect to Bindplane
+func (p *functionProvider) Configure(context.Context, fwprovider.ConfigureRequest, *fwprovider.ConfigureResponse) {
+}
+
+// Resources returns no resources, they are defined by the SDK provider
+func (p *functionProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+// DataSources returns no data sources, they are defined by the SDK provider
+func (p *functionProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// Functions returns the provider's functions
+func (p *functionProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		func() function.Function { return parametersFunction{} },
+		func() function.Function { return conditionOTTLFunction{} },
+		func() function.Function { return conditionUIFunction{} },
+		func() function.Function { return routeComponentFunction{} },
+	}
+}
+
+// frameworkProviderSchema returns the plugin framework equivalent
+// of a plugin SDK provider schema.
+func frameworkProviderSchema(sdkSchema map[string]*schema.Schema) (fwschema.Schema, error) {
+	attributes := map[string]fwschema.Attribute{}
+	for name, s := range sdkSchema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{
+				Optional:    s.Optional,
+				Required:    s.Required,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		default:
+			return fwschema.Schema{}, fmt.Errorf("provider option %s has unsupported type %s", name, s.Type)
+		}
+	}
+	return fwschema.Schema{Attributes: attributes}, nil
+}
+
+// parametersFunction implements provider::bindplane::parameters
+type parametersFunction struct{}
+
+// Metadata returns the function's name
+func (parametersFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parameters"
+}
+
+// Definition returns the function's parameters and return type
+func (parametersFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a parameters_json value",
+		Description: "Returns the JSON parameters of a source, processor, destination, extension, or connector from an object of parameter names and values. Condition parameters are validated.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "parameters",
+				Description: "An object or map of parameter names and values.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the parameters as JSON
+func (parametersFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arg types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &arg)
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := goValue(arg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	values, ok := value.(map[string]any)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "parameters must be an object or map")
+		return
+	}
+
+	parameters, err := parameter.FromMap(values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("marshal parameters: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, string(b))
+}
+
+// conditionOTTLFunction implements provider::bindplane::condition_ottl
+type conditionOTTLFunction struct{}
+
+// Metadata returns the function's name
+func (conditionOTTLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_ottl"
+}
+
+// Definition returns the function's parameters and return type
+func (conditionOTTLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an OTTL condition",
+		Description: "Returns the value of a condition parameter from an OTTL expression and its OTTL context. The expression is validated.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "The OTTL condition expression.",
+			},
+			function.StringParameter{
+				Name:        "context",
+				Description: "The OTTL context of the expression, such as log or resource. An empty context only checks the expression's syntax and converters.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+// Run returns the condition
+func (conditionOTTLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression, ottlContext string
+	resp.Error = req.Arguments.Get(ctx, &expression, &ottlContext)
+	if resp.Error != nil {
+		return
+	}
+
+	if ottlContext != "" {
+		if err := parameter.ValidateOTTLContext(ottlContext); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+			return
+		}
+	}
+
+	condition, err := parameter.OTTLCondition(expression, ottlContext)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	attrTypes := map[string]attr.Type{}
+	attrs := map[string]attr.Value{}
+	for k, v := range condition {
+		attrTypes[k] = types.StringType
+		attrs[k] = types.StringValue(v.(string))
+	}
+	obj, diags := types.ObjectValue(attrTypes, attrs)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(obj))
+}
+
+// conditionUIFunction implements provider::bindplane::condition_ui
+type conditionUIFunction struct{}
+
+// Metadata returns the function's name
+func (conditionUIFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition_ui"
+}
+
+// Definition returns the function's parameters and return type
+func (conditionUIFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a condition UI condition",
+		Description: "Returns the value of a condition parameter from a condition UI statement, such as { operator = \"==\", key = \"env\", match = \"resource\", value = \"prod\" }. The statement is validated.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "statement",
+				Description: "The condition UI statement.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+// Run returns the condition
+func (conditionUIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arg types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &arg)
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := goValue(arg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	statement, ok := value.(map[string]any)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "statement must be an object")
+		return
+	}
+	if _, err := parameter.UICondition(statement); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	// The statement is returned as is, under the ui field
+	underlying := arg.UnderlyingValue()
+	obj, diags := types.ObjectValue(
+		map[string]attr.Type{"ui": underlying.Type(ctx)},
+		map[string]attr.Value{"ui": underlying},
+	)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(obj))
+}
+
+// routeComponentFunction implements provider::bindplane::route_component
+type routeComponentFunction struct{}
+
+// Metadata returns the function's name
+func (routeComponentFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "route_component"
+}
+
+// Definition returns the function's parameters and return type
+func (routeComponentFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a route component",
+		Description: "Returns the route component of a processor group, destination, or connector, such as destinations/google.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "kind",
+				Description: "The component kind: processor, destination, or connector. The plural form is accepted as well.",
+			},
+			function.StringParameter{
+				Name:        "route_id",
+				Description: "The route ID of the component.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the route component
+func (routeComponentFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var kind, id string
+	resp.Error = req.Arguments.Get(ctx, &kind, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	if _, err := component.RoutePrefix(kind); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if err := component.ValidateRouteID(id); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	path, err := component.RouteComponent(kind, id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, string(path))
+}
+
+// goValue returns the Go value of a Terraform value, the same as
+// jsondecode(jsonencode(v)) would. Whole numbers are returned as int64.
+func goValue(v attr.Value) (any, error) {
+	if v == nil || v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, errors.New("value is not known")
+	}
+
+	switch v := v.(type) {
+	case types.Dynamic:
+		return goValue(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		f := v.ValueBigFloat()
+		if f.IsInt() {
+			if i, acc := f.Int64(); acc == big.Exact {
+				return i, nil
+			}
+		}
+		n, _ := f.Float64()
+		return n, nil
+	case types.List:
+		return goValues(v.Elements())
+	case types.Set:
+		return goValues(v.Elements())
+	case types.Tuple:
+		return goValues(v.Elements())
+	case types.Map:
+		return goMap(v.Elements())
+	case types.Object:
+		return goMap(v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", v.Type(context.Background()))
+	}
+}
+
+// goValues returns the Go values of a list of Terraform values
+func goValues(elements []attr.Value) ([]any, error) {
+	values := make([]any, 0, len(elements))
+	for i, e := range elements {
+		v, err := goValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// goMap returns the Go values of a map of Terraform values
+func goMap(elements map[string]attr.Value) (map[string]any, error) {
+	values := make(map[string]any, len(elements))
+	for k, e := range elements {
+		v, err := goValue(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
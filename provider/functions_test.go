@@ -0,0 +1,215 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderServerFunctions(t *testing.T) {
+	ctx := context.Background()
+	server, err := ProviderServer(ctx)
+	require.NoError(t, err)
+
+	// The SDK and function providers' schemas must match to be served together
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+	require.Contains(t, schemas.ResourceSchemas, "bindplane_configuration_v2")
+	require.Len(t, schemas.Functions, 4)
+	for _, name := range []string{"parameters", "condition_ottl", "condition_ui", "route_component"} {
+		require.Contains(t, schemas.Functions, name)
+	}
+
+	arg := func(s string) *tfprotov5.DynamicValue {
+		v, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, s))
+		require.NoError(t, err)
+		return &v
+	}
+	resp, err := server.CallFunction(ctx, &tfprotov5.CallFunctionRequest{
+		Name:      "route_component",
+		Arguments: []*tfprotov5.DynamicValue{arg("destination"), arg("google")},
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Error)
+
+	result, err := resp.Result.Unmarshal(tftypes.String)
+	require.NoError(t, err)
+	var path string
+	require.NoError(t, result.As(&path))
+	require.Equal(t, "destinations/google", path)
+}
+
+// runFunction runs a function with the arguments and returns its result
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+
+	def := function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, &def)
+
+	var result attr.Value = types.StringUnknown()
+	if _, ok := def.Definition.Return.(function.DynamicReturn); ok {
+		result = types.DynamicUnknown()
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestParametersFunction(t *testing.T) {
+	object := func(attrs map[string]attr.Value) attr.Value {
+		attrTypes := map[string]attr.Type{}
+		for k, v := range attrs {
+			attrTypes[k] = v.Type(context.Background())
+		}
+		return types.DynamicValue(types.ObjectValueMust(attrTypes, attrs))
+	}
+
+	cases := []struct {
+		name      string
+		arg       attr.Value
+		expect    string
+		expectErr string
+	}{
+		{
+			"values",
+			object(map[string]attr.Value{
+				"collection_interval": types.NumberValue(big.NewFloat(30)),
+				"ratio":               types.NumberValue(big.NewFloat(0.5)),
+				"enable_process":      types.BoolValue(false),
+				"metrics":             types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("cpu")}),
+				"empty":               types.StringNull(),
+			}),
+			`[{"name":"collection_interval","value":30},{"name":"empty","value":null},{"name":"enable_process","value":false},{"name":"metrics","value":["cpu"]},{"name":"ratio","value":0.5}]`,
+			"",
+		},
+		{
+			"condition",
+			object(map[string]attr.Value{
+				"condition": object(map[string]attr.Value{
+					"ottl":        types.StringValue(`body == "error"`),
+					"ottlContext": types.StringValue("log"),
+				}),
+			}),
+			`[{"name":"condition","value":{"ottl":"body == \"error\"","ottlContext":"log"}}]`,
+			"",
+		},
+		{
+			"invalid-condition",
+			object(map[string]attr.Value{
+				"condition": types.StringValue(`attributes["env"] = "prod"`),
+			}),
+			"",
+			"parameter validation failed",
+		},
+		{
+			"not-an-object",
+			types.DynamicValue(types.StringValue("host")),
+			"",
+			"parameters must be an object or map",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := runFunction(t, parametersFunction{}, tc.arg)
+			if tc.expectErr != "" {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, types.StringValue(tc.expect), result)
+		})
+	}
+}
+
+func TestConditionOTTLFunction(t *testing.T) {
+	result, err := runFunction(t, conditionOTTLFunction{}, types.StringValue("severity_number < 9"), types.StringValue("log"))
+	require.Nil(t, err)
+	require.Equal(t, types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"ottl": types.StringType, "ottlContext": types.StringType},
+		map[string]attr.Value{"ottl": types.StringValue("severity_number < 9"), "ottlContext": types.StringValue("log")},
+	)), result)
+
+	// The context is omitted when empty
+	result, err = runFunction(t, conditionOTTLFunction{}, types.StringValue("true"), types.StringValue(""))
+	require.Nil(t, err)
+	require.Equal(t, types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"ottl": types.StringType},
+		map[string]attr.Value{"ottl": types.StringValue("true")},
+	)), result)
+
+	_, err = runFunction(t, conditionOTTLFunction{}, types.StringValue(`body = "error"`), types.StringValue("log"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid condition")
+	require.Equal(t, int64(0), *err.FunctionArgument)
+
+	_, err = runFunction(t, conditionOTTLFunction{}, types.StringValue("true"), types.StringValue("logs"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "invalid OTTL context 'logs'")
+	require.Equal(t, int64(1), *err.FunctionArgument)
+}
+
+func TestConditionUIFunction(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"operator": types.StringType,
+		"key":      types.StringType,
+		"match":    types.StringType,
+		"value":    types.StringType,
+	}
+	statement := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"operator": types.StringValue("=="),
+		"key":      types.StringValue("env"),
+		"match":    types.StringValue("resource"),
+		"value":    types.StringValue("prod"),
+	})
+
+	result, err := runFunction(t, conditionUIFunction{}, types.DynamicValue(statement))
+	require.Nil(t, err)
+	require.Equal(t, types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"ui": types.ObjectType{AttrTypes: attrTypes}},
+		map[string]attr.Value{"ui": statement},
+	)), result)
+
+	_, err = runFunction(t, conditionUIFunction{}, types.DynamicValue(types.StringValue("env == prod")))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "statement must be an object")
+}
+
+func TestRouteComponentFunction(t *testing.T) {
+	result, err := runFunction(t, routeComponentFunction{}, types.StringValue("processors"), types.StringValue("parse"))
+	require.Nil(t, err)
+	require.Equal(t, types.StringValue("processors/parse"), result)
+
+	// Errors are attached to the argument which caused them
+	_, err = runFunction(t, routeComponentFunction{}, types.StringValue("source"), types.StringValue("host"))
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), *err.FunctionArgument)
+
+	_, err = runFunction(t, routeComponentFunction{}, types.StringValue("destination"), types.StringValue("a/b"))
+	require.NotNil(t, err)
+	require.Equal(t, int64(1), *err.FunctionArgument)
+}
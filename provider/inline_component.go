@@ -0,0 +1,396 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+	"github.com/observiq/terraform-provider-bindplane/internal/parameter"
+)
+
+// inlineBlock is a configuration block which references components by
+// name or defines them inline by type. typeKind is the kind of the
+// block's component type, and is empty when the block only has
+// processors, such as a processor group.
+type inlineBlock struct {
+	key      string
+	typeKind model.Kind
+}
+
+// configurationInlineBlocks are the blocks of bindplane_configuration
+// which can define inline components.
+var configurationInlineBlocks = []inlineBlock{
+	{"source", model.KindSourceType},
+	{"destination", model.KindDestinationType},
+}
+
+// configurationV2InlineBlocks are the blocks of bindplane_configuration_v2
+// which can define inline components.
+var configurationV2InlineBlocks = []inlineBlock{
+	{"source", model.KindSourceType},
+	{"processor_group", ""},
+	{"destination", model.KindDestinationType},
+}
+
+// typeKindNames are the names of component type kinds used in errors
+var typeKindNames = map[model.Kind]string{
+	model.KindSourceType:      "source type",
+	model.KindDestinationType: "destination type",
+	model.KindProcessorType:   "processor type",
+	model.KindExtensionType:   "extension type",
+	model.KindConnectorType:   "connector type",
+}
+
+// resourceLookup returns a Bindplane resource, or nil if it does not exist.
+type resourceLookup func(kind model.Kind, name string) (*model.AnyResource, error)
+
+// customizeDiffInlineComponents validates the inline components of a
+// configuration's blocks at plan time. Each component must be referenced
+// by name or defined by type, and the type of each inline component must
+// exist in Bindplane. Blocks with values not known until apply are skipped.
+func customizeDiffInlineComponents(blocks []inlineBlock) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta any) error {
+		var lookup resourceLookup
+		if bindplane, ok := meta.(*client.BindPlane); ok {
+			lookup = bindplane.AnyResource
+		}
+
+		errs := []error{}
+		for _, b := range blocks {
+			if !d.NewValueKnown(b.key) {
+				continue
+			}
+			raw, _ := d.Get(b.key).([]any)
+			errs = append(errs, validateInlineBlocks(b, raw, lookup)...)
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// validateInlineBlocks validates each block of an inline block option,
+// and its processors. Inline component types are checked with lookup
+// when it is not nil.
+func validateInlineBlocks(b inlineBlock, blocks []any, lookup resourceLookup) []error {
+	checked := map[string]error{}
+	checkType := func(path string, typeKind model.Kind, rType string) error {
+		key := fmt.Sprintf("%s/%s", typeKind, strings.Split(rType, ":")[0])
+		err, ok := checked[key]
+		if !ok {
+			err = typeExists(lookup, typeKind, rType)
+			checked[key] = err
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return nil
+	}
+
+	errs := []error{}
+	for i, v := range blocks {
+		block, _ := v.(map[string]any)
+		if hasUnknown(block) {
+			continue
+		}
+		path := fmt.Sprintf("%s.%d", b.key, i)
+
+		rc := configuration.ResourceConfig{}
+		if b.typeKind != "" {
+			c, err := expandInlineComponent(block, path)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if c.Type != "" {
+				if err := checkType(path, b.typeKind, c.Type); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			rc = c
+		}
+
+		if err := expandProcessors(block, path, &rc); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for j, p := range rc.InlineProcessors {
+			if p.Type == "" {
+				continue
+			}
+			if err := checkType(fmt.Sprintf("%s.processor.%d", path, j), model.KindProcessorType, p.Type); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// typeExists returns an error if the component type does not exist
+// in Bindplane. The type's version is ignored. Types are not checked
+// when lookup is nil.
+func typeExists(lookup resourceLookup, typeKind model.Kind, rType string) error {
+	if lookup == nil {
+		return nil
+	}
+	rType = strings.Split(rType, ":")[0]
+	r, err := lookup(typeKind, rType)
+	if err != nil {
+		return fmt.Errorf("get %s %s: %w", typeKindNames[typeKind], rType, err)
+	}
+	if r == nil {
+		return fmt.Errorf("%s %s does not exist", typeKindNames[typeKind], rType)
+	}
+	return nil
+}
+
+// checkResourceNotExists returns an error if a resource which Terraform
+// will create already exists in Bindplane, so that the conflict is
+// reported at plan time instead of when applying.
+func checkResourceNotExists(lookup resourceLookup, rKind model.Kind, name string) error {
+	if lookup == nil || !isKnown(name) {
+		return nil
+	}
+	r, err := lookup(rKind, name)
+	if err != nil {
+		return fmt.Errorf("get %s %s: %w", rKind, name, err)
+	}
+	if r != nil {
+		return fmt.Errorf("%s with name '%s' already exists with id '%s'", rKind, name, r.ID())
+	}
+	return nil
+}
+
+// isKnown returns false for empty values and values
+// which are not known until apply.
+func isKnown(s string) bool {
+	return s != "" && s != unknownValue
+}
+
+// hasUnknown returns true if a value read from a plan contains
+// a value which is not known until apply.
+func hasUnknown(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v == unknownValue
+	case map[string]any:
+		for _, e := range v {
+			if hasUnknown(e) {
+				return true
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if hasUnknown(e) {
+				return true
+			}
+		}
+	case *schema.Set:
+		return hasUnknown(v.List())
+	}
+	return false
+}
+
+// inlineTypeSchema returns the schema of the type option of a
+// configuration block which can define an inline component.
+func inlineTypeSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    false,
+		Description: fmt.Sprintf("The %s type of an inline %s, defined within the configuration. Set either name or type.", kind, kind),
+	}
+}
+
+// inlineParametersSchema returns the schema of the parameters_json
+// option of a configuration block which can define an inline component.
+func inlineParametersSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         false,
+		Description:      fmt.Sprintf("A JSON object with options used to configure an inline %s. Only used with type.", kind),
+		DiffSuppressFunc: suppressEquivalentJSONDiffs,
+	}
+}
+
+// inlineProcessorSchema is a processor attached to a configuration
+// block, referenced by name or defined inline by type.
+var inlineProcessorSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	ForceNew: false,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    false,
+				Description: "Name of the processor to attach. Set either name or type.",
+			},
+			"type":            inlineTypeSchema("processor"),
+			"parameters_json": inlineParametersSchema("processor"),
+		},
+	},
+	Description: "Processors to attach, referenced by name or defined inline by type. Cannot be used with processors.",
+}
+
+// expandInlineComponent reads the name, type, and parameters_json options of
+// a configuration block. The block must set either name, or type with optional
+// parameters_json. path is used in error messages.
+func expandInlineComponent(block map[string]any, path string) (configuration.ResourceConfig, error) {
+	name, _ := block["name"].(string)
+	rType, _ := block["type"].(string)
+	params, _ := block["parameters_json"].(string)
+
+	switch {
+	case name != "" && rType != "":
+		return configuration.ResourceConfig{}, fmt.Errorf("%s: name and type cannot both be set, use name to reference a library component or type to define an inline component", path)
+	case name == "" && rType == "":
+		return configuration.ResourceConfig{}, fmt.Errorf("%s: one of name or type must be set", path)
+	case name != "":
+		if params != "" {
+			return configuration.ResourceConfig{}, fmt.Errorf("%s: parameters_json can only be set with type", path)
+		}
+		return configuration.ResourceConfig{Name: name}, nil
+	}
+
+	parameters := []model.Parameter{}
+	if params != "" {
+		p, err := parameter.StringToParameter(params)
+		if err != nil {
+			return configuration.ResourceConfig{}, fmt.Errorf("%s: %w", path, err)
+		}
+		parameters = p
+	}
+
+	return configuration.ResourceConfig{
+		Type:       rType,
+		Parameters: parameters,
+	}, nil
+}
+
+// expandProcessors reads the processors and processor options of a
+// configuration block into rc. Only one of the options can be set.
+func expandProcessors(block map[string]any, path string, rc *configuration.ResourceConfig) error {
+	names, _ := block["processors"].([]any)
+	inline, _ := block["processor"].([]any)
+
+	if len(names) > 0 && len(inline) > 0 {
+		return fmt.Errorf("%s: processors and processor cannot both be set", path)
+	}
+
+	for _, v := range names {
+		rc.Processors = append(rc.Processors, v.(string))
+	}
+
+	for i, v := range inline {
+		p, _ := v.(map[string]any)
+		processor, err := expandInlineComponent(p, fmt.Sprintf("%s.processor.%d", path, i))
+		if err != nil {
+			return err
+		}
+		rc.InlineProcessors = append(rc.InlineProcessors, processor)
+	}
+
+	return nil
+}
+
+// flattenInlineComponent sets the name, or type and parameters_json, of a
+// component read from Bindplane on the block. state is the block previously
+// saved to state, used to preserve sensitive parameter values. It can be nil.
+func flattenInlineComponent(rc model.ResourceConfiguration, state map[string]any, block map[string]any) error {
+	if rc.Name != "" {
+		block["name"] = strings.Split(rc.Name, ":")[0]
+		return nil
+	}
+
+	block["type"] = strings.Split(rc.Type, ":")[0]
+
+	stateParams := []model.Parameter{}
+	if s, _ := state["parameters_json"].(string); s != "" {
+		// State is not validated, a condition which is no longer
+		// valid must not prevent the component from being read.
+		if err := json.Unmarshal([]byte(s), &stateParams); err != nil {
+			return fmt.Errorf("state parameters: %w", err)
+		}
+	}
+
+	// Update all sensitive parameters with the values from state
+	// instead of saving "(sensitive value)" to state.
+	params, err := parameter.ParametersToString(parameter.MergeSensitive(rc.Parameters, stateParams))
+	if err != nil {
+		return err
+	}
+	block["parameters_json"] = params
+	return nil
+}
+
+// flattenProcessors sets the processors attached to a component read from
+// Bindplane on the block. The processor option is used when the block in
+// state uses it, or when a processor is inline. Otherwise processors is used.
+func flattenProcessors(processors []model.ResourceConfiguration, state map[string]any, block map[string]any) error {
+	stateInline, _ := state["processor"].([]any)
+
+	useInline := len(stateInline) > 0
+	for _, p := range processors {
+		if p.Name == "" {
+			useInline = true
+		}
+	}
+
+	if !useInline {
+		names := []string{}
+		for _, p := range processors {
+			names = append(names, strings.Split(p.Name, ":")[0])
+		}
+		block["processors"] = names
+		block["processor"] = []any{}
+		return nil
+	}
+
+	inline := []any{}
+	for i, p := range processors {
+		var stateProcessor map[string]any
+		if i < len(stateInline) {
+			stateProcessor, _ = stateInline[i].(map[string]any)
+		}
+
+		processor := map[string]any{}
+		if err := flattenInlineComponent(p, stateProcessor, processor); err != nil {
+			return err
+		}
+		inline = append(inline, processor)
+	}
+	block["processors"] = []string{}
+	block["processor"] = inline
+	return nil
+}
+
+// stateBlock returns the block at index i of a list saved
+// to state, or nil if there is no such block.
+func stateBlock(blocks []any, i int) map[string]any {
+	if i >= len(blocks) {
+		return nil
+	}
+	block, _ := blocks[i].(map[string]any)
+	return block
+}
@@ -0,0 +1,262 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandInlineComponent(t *testing.T) {
+	cases := []struct {
+		name      string
+		block     map[string]any
+		expect    configuration.ResourceConfig
+		expectErr string
+	}{
+		{
+			"name",
+			map[string]any{"name": "my-host"},
+			configuration.ResourceConfig{Name: "my-host"},
+			"",
+		},
+		{
+			"type",
+			map[string]any{"type": "host", "parameters_json": `[{"name":"collection_interval","value":30}]`},
+			configuration.ResourceConfig{
+				Type: "host",
+				Parameters: []model.Parameter{
+					{Name: "collection_interval", Value: float64(30)},
+				},
+			},
+			"",
+		},
+		{
+			"type-without-parameters",
+			map[string]any{"type": "host", "parameters_json": ""},
+			configuration.ResourceConfig{Type: "host", Parameters: []model.Parameter{}},
+			"",
+		},
+		{
+			"name-and-type",
+			map[string]any{"name": "my-host", "type": "host"},
+			configuration.ResourceConfig{},
+			"source.0: name and type cannot both be set",
+		},
+		{
+			"neither",
+			map[string]any{"name": "", "type": ""},
+			configuration.ResourceConfig{},
+			"source.0: one of name or type must be set",
+		},
+		{
+			"name-with-parameters",
+			map[string]any{"name": "my-host", "parameters_json": `[]`},
+			configuration.ResourceConfig{},
+			"source.0: parameters_json can only be set with type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := expandInlineComponent(tc.block, "source.0")
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, output)
+		})
+	}
+}
+
+func TestExpandProcessors(t *testing.T) {
+	rc := configuration.ResourceConfig{}
+	err := expandProcessors(map[string]any{
+		"processors": []any{"batch", "filter"},
+	}, "source.0", &rc)
+	require.NoError(t, err)
+	require.Equal(t, []string{"batch", "filter"}, rc.Processors)
+	require.Empty(t, rc.InlineProcessors)
+
+	rc = configuration.ResourceConfig{}
+	err = expandProcessors(map[string]any{
+		"processor": []any{
+			map[string]any{"name": "batch"},
+			map[string]any{"type": "count_telemetry"},
+		},
+	}, "source.0", &rc)
+	require.NoError(t, err)
+	require.Empty(t, rc.Processors)
+	require.Equal(t, []configuration.ResourceConfig{
+		{Name: "batch"},
+		{Type: "count_telemetry", Parameters: []model.Parameter{}},
+	}, rc.InlineProcessors)
+
+	err = expandProcessors(map[string]any{
+		"processors": []any{"batch"},
+		"processor":  []any{map[string]any{"name": "batch"}},
+	}, "source.0", &configuration.ResourceConfig{})
+	require.ErrorContains(t, err, "source.0: processors and processor cannot both be set")
+
+	err = expandProcessors(map[string]any{
+		"processor": []any{map[string]any{}},
+	}, "source.0", &configuration.ResourceConfig{})
+	require.ErrorContains(t, err, "source.0.processor.0: one of name or type must be set")
+}
+
+func TestFlattenInlineComponent(t *testing.T) {
+	block := map[string]any{}
+	err := flattenInlineComponent(model.ResourceConfiguration{Name: "my-host:2"}, nil, block)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"name": "my-host"}, block)
+
+	// Sensitive values are masked by Bindplane and
+	// should be read back from state.
+	rc := model.ResourceConfiguration{
+		ParameterizedSpec: model.ParameterizedSpec{
+			Type: "otlp_grpc:3",
+			Parameters: []model.Parameter{
+				{Name: "hostname", Value: "otlp.example.com"},
+				{Name: "api_key", Value: "(sensitive)", Sensitive: true},
+			},
+		},
+	}
+	state := map[string]any{
+		"type":            "otlp_grpc",
+		"parameters_json": `[{"name":"hostname","value":"otlp.example.com"},{"name":"api_key","value":"secret"}]`,
+	}
+	block = map[string]any{}
+	err = flattenInlineComponent(rc, state, block)
+	require.NoError(t, err)
+	require.Equal(t, "otlp_grpc", block["type"])
+	require.JSONEq(t, `[{"name":"hostname","value":"otlp.example.com"},{"name":"api_key","value":"secret"}]`, block["parameters_json"].(string))
+
+	// Conditions in state are not validated when reading
+	rc = model.ResourceConfiguration{
+		ParameterizedSpec: model.ParameterizedSpec{
+			Type:       "filter_by_condition:1",
+			Parameters: []model.Parameter{{Name: "condition", Value: `Unknown(body)`}},
+		},
+	}
+	state = map[string]any{
+		"type":            "filter_by_condition",
+		"parameters_json": `[{"name":"condition","value":"Unknown(body)"}]`,
+	}
+	block = map[string]any{}
+	require.NoError(t, flattenInlineComponent(rc, state, block))
+	require.JSONEq(t, `[{"name":"condition","value":"Unknown(body)"}]`, block["parameters_json"].(string))
+}
+
+func TestFlattenProcessors(t *testing.T) {
+	byName := []model.ResourceConfiguration{{Name: "batch:1"}, {Name: "filter:4"}}
+
+	block := map[string]any{}
+	require.NoError(t, flattenProcessors(byName, nil, block))
+	require.Equal(t, []string{"batch", "filter"}, block["processors"])
+	require.Equal(t, []any{}, block["processor"])
+
+	// The processor option is kept when state uses it
+	state := map[string]any{"processor": []any{map[string]any{"name": "batch"}}}
+	block = map[string]any{}
+	require.NoError(t, flattenProcessors(byName, state, block))
+	require.Equal(t, []string{}, block["processors"])
+	require.Equal(t, []any{
+		map[string]any{"name": "batch"},
+		map[string]any{"name": "filter"},
+	}, block["processor"])
+
+	// Inline processors always use the processor option
+	inline := []model.ResourceConfiguration{
+		{ParameterizedSpec: model.ParameterizedSpec{Type: "batch:2"}},
+	}
+	block = map[string]any{}
+	require.NoError(t, flattenProcessors(inline, nil, block))
+	require.Equal(t, []any{
+		map[string]any{"type": "batch", "parameters_json": ""},
+	}, block["processor"])
+}
+
+func TestValidateInlineBlocks(t *testing.T) {
+	types := map[string]*model.AnyResource{
+		"SourceType/hostmetrics": {},
+		"ProcessorType/batch":    {},
+	}
+	lookups := 0
+	lookup := func(kind model.Kind, name string) (*model.AnyResource, error) {
+		lookups++
+		return types[string(kind)+"/"+name], nil
+	}
+	sources := inlineBlock{"source", model.KindSourceType}
+
+	errs := validateInlineBlocks(sources, []any{
+		map[string]any{"name": "library-source"},
+		map[string]any{"type": "hostmetrics:2", "processor": []any{
+			map[string]any{"type": "batch"},
+			map[string]any{"name": "library-processor"},
+		}},
+		map[string]any{"type": "hostmetrics"},
+	}, lookup)
+	require.Empty(t, errs)
+	require.Equal(t, 2, lookups, "types are looked up once")
+
+	errs = validateInlineBlocks(sources, []any{
+		map[string]any{"name": "a", "type": "hostmetrics"},
+		map[string]any{},
+		map[string]any{"type": "missing"},
+		map[string]any{"type": "hostmetrics", "processor": []any{map[string]any{"type": "missing"}}},
+		map[string]any{"name": "a", "processors": []any{"b"}, "processor": []any{map[string]any{"name": "c"}}},
+	}, lookup)
+	require.Len(t, errs, 5)
+	require.EqualError(t, errs[0], "source.0: name and type cannot both be set, use name to reference a library component or type to define an inline component")
+	require.EqualError(t, errs[1], "source.1: one of name or type must be set")
+	require.EqualError(t, errs[2], "source.2: source type missing does not exist")
+	require.EqualError(t, errs[3], "source.3.processor.0: processor type missing does not exist")
+	require.EqualError(t, errs[4], "source.4: processors and processor cannot both be set")
+
+	// Blocks which are not known until apply are skipped
+	errs = validateInlineBlocks(sources, []any{map[string]any{"name": unknownValue, "type": "missing"}}, lookup)
+	require.Empty(t, errs)
+
+	// Processor groups only have processors
+	errs = validateInlineBlocks(inlineBlock{"processor_group", ""}, []any{
+		map[string]any{"processor": []any{map[string]any{"type": "missing"}}},
+	}, lookup)
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], "processor_group.0.processor.0: processor type missing does not exist")
+
+	// Types are not checked without a lookup
+	errs = validateInlineBlocks(sources, []any{map[string]any{"type": "missing"}}, nil)
+	require.Empty(t, errs)
+}
+
+func TestCheckResourceNotExists(t *testing.T) {
+	existing := &model.AnyResource{}
+	existing.Metadata.ID = "01HZ"
+	lookup := func(kind model.Kind, name string) (*model.AnyResource, error) {
+		if name == "taken" {
+			return existing, nil
+		}
+		return nil, nil
+	}
+
+	require.NoError(t, checkResourceNotExists(lookup, model.KindSourceType, "new"))
+	require.NoError(t, checkResourceNotExists(lookup, model.KindSourceType, unknownValue))
+	require.NoError(t, checkResourceNotExists(nil, model.KindSourceType, "taken"))
+	require.EqualError(t, checkResourceNotExists(lookup, model.KindSourceType, "taken"), "SourceType with name 'taken' already exists with id '01HZ'")
+}
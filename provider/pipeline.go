@@ -0,0 +1,138 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+)
+
+// readPipelines returns the pipelines defined by pipeline blocks. Returns
+// false if a telemetry type or component name is not known until apply.
+func readPipelines(raw []any) ([]component.Pipeline, bool) {
+	pipelines := []component.Pipeline{}
+	known := true
+	for _, v := range raw {
+		block, _ := v.(map[string]any)
+		telemetryTypes, _ := block["telemetry_types"].([]any)
+		sources, _ := block["sources"].([]any)
+		processors, _ := block["processors"].([]any)
+		destinations, _ := block["destinations"].([]any)
+		p := component.Pipeline{
+			TelemetryTypes: stringList(telemetryTypes),
+			Sources:        stringList(sources),
+			Processors:     stringList(processors),
+			Destinations:   stringList(destinations),
+		}
+
+		// Required lists read back as empty when they contain
+		// a value which is not known until apply.
+		if len(p.TelemetryTypes) == 0 || len(p.Sources) == 0 || len(p.Destinations) == 0 {
+			known = false
+		}
+		for _, list := range [][]string{p.TelemetryTypes, p.Sources, p.Processors, p.Destinations} {
+			for _, s := range list {
+				if !isKnown(s) {
+					known = false
+				}
+			}
+		}
+
+		pipelines = append(pipelines, p)
+	}
+	return pipelines, known
+}
+
+// expandPipelines returns the sources, processor groups, and destinations
+// generated for the pipeline blocks. Returns an error if a pipeline source
+// or destination is also configured by a block, or a block uses a route ID
+// reserved for pipelines.
+func expandPipelines(raw []any, sources, processorGroups, destinations []configuration.ResourceConfig) (component.PipelineComponents, error) {
+	pipelines, _ := readPipelines(raw)
+	c, err := component.ExpandPipelines(pipelines)
+	if err != nil {
+		return c, err
+	}
+
+	for _, s := range c.Sources {
+		for i, block := range sources {
+			if block.Name == s.Name {
+				return c, fmt.Errorf("source.%d: source '%s' is also used by a pipeline, configure it with a source block or a pipeline, not both", i, s.Name)
+			}
+		}
+	}
+
+	for _, d := range c.Destinations {
+		for i, block := range destinations {
+			if block.Name == d.Name {
+				return c, fmt.Errorf("destination.%d: destination '%s' is also used by a pipeline, configure it with a destination block or a pipeline, not both", i, d.Name)
+			}
+		}
+	}
+
+	reserved := component.PipelineRouteIDPrefix + "-"
+	for _, b := range []struct {
+		key    string
+		blocks []configuration.ResourceConfig
+	}{
+		{"processor_group", processorGroups},
+		{"destination", destinations},
+	} {
+		for i, block := range b.blocks {
+			if strings.HasPrefix(block.RouteID, reserved) {
+				return c, fmt.Errorf("%s.%d: route_id '%s' is reserved for pipelines, route IDs cannot start with '%s'", b.key, i, block.RouteID, reserved)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// pipelineResourceConfigs converts pipeline components
+// to configuration.ResourceConfig.
+func pipelineResourceConfigs(components []model.ResourceConfiguration) []configuration.ResourceConfig {
+	configs := []configuration.ResourceConfig{}
+	for _, c := range components {
+		processors := []string{}
+		for _, p := range c.Processors {
+			processors = append(processors, p.Name)
+		}
+		configs = append(configs, configuration.ResourceConfig{
+			Name:       c.Name,
+			Processors: processors,
+			RouteID:    c.ID,
+			Routes:     c.Routes,
+		})
+	}
+	return configs
+}
+
+// flattenPipelines returns pipelines as pipeline blocks
+func flattenPipelines(pipelines []component.Pipeline) []map[string]any {
+	blocks := []map[string]any{}
+	for _, p := range pipelines {
+		blocks = append(blocks, map[string]any{
+			"telemetry_types": p.TelemetryTypes,
+			"sources":         p.Sources,
+			"processors":      p.Processors,
+			"destinations":    p.Destinations,
+		})
+	}
+	return blocks
+}
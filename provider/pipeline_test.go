@@ -0,0 +1,160 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPipelines(t *testing.T) {
+	raw := map[string]any{
+		"name":     "my-config",
+		"platform": "linux",
+		"rollout":  true,
+		"pipeline": []any{
+			map[string]any{
+				"telemetry_types": []any{"logs"},
+				"sources":         []any{"host"},
+				"processors":      []any{"batch"},
+				"destinations":    []any{"google"},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	pipelines, known := readPipelines(d.Get("pipeline").([]any))
+	require.True(t, known)
+	require.Equal(t, []component.Pipeline{
+		{
+			TelemetryTypes: []string{"logs"},
+			Sources:        []string{"host"},
+			Processors:     []string{"batch"},
+			Destinations:   []string{"google"},
+		},
+	}, pipelines)
+
+	blocks := flattenPipelines(pipelines)
+	require.NoError(t, d.Set("pipeline", blocks))
+	require.Equal(t, raw["pipeline"], d.Get("pipeline"))
+
+	// Names which are not known until apply skip validation
+	raw["pipeline"].([]any)[0].(map[string]any)["sources"] = []any{unknownValue}
+	d = schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	_, known = readPipelines(d.Get("pipeline").([]any))
+	require.False(t, known)
+}
+
+func TestExpandPipelinesConflicts(t *testing.T) {
+	raw := []any{
+		map[string]any{
+			"telemetry_types": []any{"logs"},
+			"sources":         []any{"host"},
+			"processors":      []any{},
+			"destinations":    []any{"google"},
+		},
+	}
+
+	c, err := expandPipelines(raw, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, c.Sources, 1)
+	require.Empty(t, c.Processors)
+	require.Len(t, c.Destinations, 1)
+
+	configs := pipelineResourceConfigs(c.Destinations)
+	require.Equal(t, []configuration.ResourceConfig{{Name: "google", Processors: []string{}, RouteID: "pipeline-google"}}, configs)
+
+	cases := []struct {
+		name            string
+		sources         []configuration.ResourceConfig
+		processorGroups []configuration.ResourceConfig
+		destinations    []configuration.ResourceConfig
+		expectErr       string
+	}{
+		{
+			"source",
+			[]configuration.ResourceConfig{{Name: "files"}, {Name: "host"}},
+			nil,
+			nil,
+			"source.1: source 'host' is also used by a pipeline, configure it with a source block or a pipeline, not both",
+		},
+		{
+			"destination",
+			nil,
+			nil,
+			[]configuration.ResourceConfig{{Name: "google", RouteID: "google"}},
+			"destination.0: destination 'google' is also used by a pipeline, configure it with a destination block or a pipeline, not both",
+		},
+		{
+			"reserved-route-id",
+			nil,
+			[]configuration.ResourceConfig{{RouteID: "pipeline-0"}},
+			nil,
+			"processor_group.0: route_id 'pipeline-0' is reserved for pipelines, route IDs cannot start with 'pipeline-'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := expandPipelines(raw, tc.sources, tc.processorGroups, tc.destinations)
+			require.EqualError(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestRouteNodesPipelines(t *testing.T) {
+	raw := map[string]any{
+		"name":     "my-config",
+		"platform": "linux",
+		"rollout":  true,
+		"source": []any{
+			map[string]any{
+				"name": "files",
+				"route": []any{
+					map[string]any{
+						"route_id":       "files",
+						"telemetry_type": "logs",
+						"components":     []any{"destinations/pipeline-google"},
+					},
+				},
+			},
+		},
+		"pipeline": []any{
+			map[string]any{
+				"telemetry_types": []any{"logs", "metrics"},
+				"sources":         []any{"host"},
+				"processors":      []any{"batch"},
+				"destinations":    []any{"google"},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	nodes, known, err := routeNodes(d.Get)
+	require.NoError(t, err)
+	require.True(t, known)
+	require.Len(t, nodes, 4)
+	require.Equal(t, "pipeline source 'host'", nodes[1].Block)
+	require.Equal(t, "pipeline processor group 'pipeline-0'", nodes[2].Block)
+	require.Equal(t, "pipeline destination 'google'", nodes[3].Block)
+
+	// Blocks can route to components generated for pipelines
+	require.NoError(t, errors.Join(component.ValidateRouteGraph(nodes)...))
+}
@@ -29,14 +29,15 @@ import (
 )
 
 const (
-	envAPIKey    = "BINDPLANE_TF_API_KEY" // #nosec G101 this is not a credential
-	envRemoteURL = "BINDPLANE_TF_REMOTE_URL"
-	envUsername  = "BINDPLANE_TF_USERNAME" // #nosec, credentials are not hardcoded
-	envPassword  = "BINDPLANE_TF_PASSWORD" // #nosec, credentials are not hardcoded
-	envTLSCa     = "BINDPLANE_TF_TLS_CA"
-	envTLSCrt    = "BINDPLANE_TF_TLS_CERT"
+	envAPIKey         = "BINDPLANE_TF_API_KEY" // #nosec G101 this is not a credential
+	envRemoteURL      = "BINDPLANE_TF_REMOTE_URL"
+	envUsername       = "BINDPLANE_TF_USERNAME" // #nosec, credentials are not hardcoded
+	envPassword       = "BINDPLANE_TF_PASSWORD" // #nosec, credentials are not hardcoded
+	envTLSCa          = "BINDPLANE_TF_TLS_CA"
+	envTLSCrt         = "BINDPLANE_TF_TLS_CERT"
 	envTLSKey         = "BINDPLANE_TF_TLS_KEY"
 	envTLSSkipVerify  = "BINDPLANE_TF_TLS_SKIP_VERIFY"
+	envLastWriterWins = "BINDPLANE_TF_LAST_WRITER_WINS"
 
 	// Timeout (including retries) for resources
 	maxTimeout = time.Minute * 5
@@ -121,16 +122,40 @@ func Configure() *schema.Provider {
 				}, nil),
 				Description: "Disables TLS certificate verification. Should only be used for testing.",
 			},
+			"last_writer_wins": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envLastWriterWins,
+				}, false),
+				Description: "Disables the check which prevents Terraform from overwriting resources that were modified in Bindplane since Terraform last read them. When enabled, the last writer wins.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"bindplane_connector":        resourceConnector(),
-			"bindplane_configuration":    resourceConfiguration(),
-			"bindplane_configuration_v2": resourceConfigurationV2(),
-			"bindplane_destination":      resourceDestination(),
-			"bindplane_source":           resourceSource(),
-			"bindplane_processor":        resourceProcessor(),
-			"bindplane_processor_bundle": resourceProcessorBundle(),
-			"bindplane_extension":        resourceExtension(),
+			"bindplane_connector":              resourceConnector(),
+			"bindplane_configuration":          resourceConfiguration(),
+			"bindplane_configuration_v2":       resourceConfigurationV2(),
+			"bindplane_raw_configuration":      resourceRawConfiguration(),
+			"bindplane_configuration_rollback": resourceConfigurationRollback(),
+			"bindplane_destination":            resourceDestination(),
+			"bindplane_source":                 resourceSource(),
+			"bindplane_processor":              resourceProcessor(),
+			"bindplane_processor_bundle":       resourceProcessorBundle(),
+			"bindplane_extension":              resourceExtension(),
+			"bindplane_resource":               resourceManifest(),
+			"bindplane_source_type":            resourceSourceType(),
+			"bindplane_destination_type":       resourceDestinationType(),
+			"bindplane_processor_type":         resourceProcessorType(),
+			"bindplane_extension_type":         resourceExtensionType(),
+			"bindplane_connector_type":         resourceConnectorType(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"bindplane_dependents":             dataSourceDependents(),
+			"bindplane_resource_type":          dataSourceResourceType(),
+			"bindplane_configuration_versions": dataSourceConfigurationVersions(),
+			"bindplane_rendered_configuration": dataSourceRenderedConfiguration(),
+			"bindplane_configuration_graph":    dataSourceConfigurationGraph(),
+			"bindplane_server":                 dataSourceServer(),
 		},
 	}
 }
@@ -198,7 +223,21 @@ func providerConfigure(d *schema.ResourceData, _ *schema.Provider) (any, diag.Di
 		return nil, diag.FromErr(err)
 	}
 
-	return &client.BindPlane{
+	bindplane := &client.BindPlane{
 		Client: c,
-	}, nil
+	}
+
+	if v, ok := d.Get("last_writer_wins").(bool); ok && v {
+		bindplane.LastWriterWins = v
+	}
+
+	// Fetch the server version so that resources can check feature
+	// support during plan. Errors are not fatal, the server may not
+	// be reachable yet. The failure is cached by the client, and
+	// unsupported features will be rejected by the server instead.
+	if _, err := bindplane.ServerVersion(); err != nil {
+		logger.Warn("Bindplane server version is unknown, feature gates will not be checked", zap.Error(err))
+	}
+
+	return bindplane, nil
 }
@@ -57,3 +57,56 @@ var RouteSchema *schema.Schema = &schema.Schema{
 	},
 	Description: "Route telemetry to specific components.",
 }
+
+// PipelineSchema defines the schema for a pipeline, which is
+// expanded into source routes, a processor group, and destinations.
+var PipelineSchema *schema.Schema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	ForceNew: false,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"telemetry_types": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: false,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+						telemetryType := val.(string)
+						if err := component.ValidateRouteType(telemetryType); err != nil {
+							errs = append(errs, err)
+						}
+						return
+					},
+				},
+				Description: "The telemetry types to route. Valid types include 'logs', 'metrics', or 'traces'.",
+			},
+			"sources": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    false,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of source names to route telemetry from.",
+			},
+			"processors": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    false,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of processor names to route telemetry through, in order.",
+			},
+			"destinations": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    false,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of destination names to route telemetry to.",
+			},
+		},
+	},
+	Description: "Route telemetry from sources, through processors, to destinations. The provider generates the equivalent source routes, processor group, and destinations.",
+}
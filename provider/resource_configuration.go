@@ -27,15 +27,16 @@ import (
 	"github.com/observiq/terraform-provider-bindplane/internal/maputil"
 	"github.com/observiq/terraform-provider-bindplane/internal/resource"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConfigurationCreate,
-		Update: resourceConfigurationCreate, // Run create as update
-		Read:   resourceConfigurationRead,
-		Delete: genericConfigurationDelete,
+		Create:        resourceConfigurationCreate,
+		Update:        resourceConfigurationCreate, // Run create as update
+		ReadContext:   readWithDriftWarnings(model.KindConfiguration, resourceConfigurationRead),
+		DeleteContext: genericConfigurationDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -74,8 +75,9 @@ func resourceConfiguration() *schema.Resource {
 				Type:        schema.TypeMap,
 				Computed:    true,
 				ForceNew:    false,
-				Description: "Labels that Bindplane uses to determine which agents the configuration should apply to. This value is computed by Terraform and is not user configurable.",
+				Description: "Labels that Bindplane uses to determine which agents the configuration should apply to. This value is computed from the selector block.",
 			},
+			"selector": selectorSchema,
 			"source": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -84,10 +86,12 @@ func resourceConfiguration() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							ForceNew:    false,
-							Description: "Name of the source to attach.",
+							Description: "Name of the source to attach. Set either name or type.",
 						},
+						"type":            inlineTypeSchema("source"),
+						"parameters_json": inlineParametersSchema("source"),
 						"processors": {
 							Type:        schema.TypeList,
 							Optional:    true,
@@ -95,9 +99,10 @@ func resourceConfiguration() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Description: "List of processor names to attach to the source.",
 						},
+						"processor": inlineProcessorSchema,
 					},
 				},
-				Description: "Source name and list of processor names to attach to the configuration. This option can be configured one or many times.",
+				Description: "Source name or inline source type, and the processors to attach to the configuration. This option can be configured one or many times.",
 			},
 			"destination": {
 				Type:     schema.TypeList,
@@ -107,10 +112,12 @@ func resourceConfiguration() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							ForceNew:    false,
-							Description: "Name of the destination to attach.",
+							Description: "Name of the destination to attach. Set either name or type.",
 						},
+						"type":            inlineTypeSchema("destination"),
+						"parameters_json": inlineParametersSchema("destination"),
 						"processors": {
 							Type:        schema.TypeList,
 							Optional:    true,
@@ -118,9 +125,10 @@ func resourceConfiguration() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Description: "List of processor names to attach to the destination.",
 						},
+						"processor": inlineProcessorSchema,
 					},
 				},
-				Description: "Destination name and list of processor names to attach to the configuration. This option can be configured one or many times.",
+				Description: "Destination name or inline destination type, and the processors to attach to the configuration. This option can be configured one or many times.",
 			},
 			"extensions": {
 				Type:        schema.TypeList,
@@ -148,65 +156,16 @@ func resourceConfiguration() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
-			"rollout_options": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: false,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
-								t := val.(string)
-								if t != "standard" && t != "progressive" {
-									errs = append(errs, fmt.Errorf("invalid rollout type: %s", t))
-								}
-								return
-							},
-							ForceNew:    false,
-							Description: "The type of rollout to perform. Valid values are 'standard' and 'progressive'.",
-						},
-						"parameters": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Name of the parameter.",
-									},
-									"value": {
-										Type:     schema.TypeList,
-										Required: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"labels": {
-													Type:        schema.TypeMap,
-													Required:    true,
-													Description: "Labels for the parameter.",
-												},
-												"name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Name of the stage.",
-												},
-											},
-										},
-										Description: "Value of the parameter, which is a list of stages.",
-									},
-								},
-							},
-							Description: "List of parameters for the rollout options.",
-						},
-					},
-				},
-				Description: "Options for configuring the rollout behavior of the configuration.",
-			},
-			"advanced": advancedSchema,
+			"rollout_options": rolloutOptionsSchema,
+			"advanced":        advancedSchema,
+			"version":         versionSchema,
+			"current_version": currentVersionSchema,
+			"pending_version": pendingVersionSchema,
 		},
+		CustomizeDiff: customdiff.All(
+			configurationCustomizeDiff("bindplane_configuration"),
+			customizeDiffInlineComponents(configurationInlineBlocks),
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -221,6 +180,10 @@ func resourceConfigurationCreate(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindConfiguration, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -240,28 +203,27 @@ func resourceConfigurationCreate(d *schema.ResourceData, meta any) error {
 	}
 	labels["platform"] = d.Get("platform").(string)
 
-	// Match labels should always be configuration=<name>
-	matchLabels := map[string]string{
-		"configuration": name,
+	// Match labels default to configuration=<name> when
+	// the selector block is not set.
+	matchLabels, err := configurationMatchLabels(d.Get, name)
+	if err != nil {
+		return err
 	}
 
 	// List of sources and their processors
 	sources := []configuration.ResourceConfig{}
 	if d.Get("source") != nil {
 		sourcesRaw := d.Get("source").([]any)
-		for _, v := range sourcesRaw {
+		for i, v := range sourcesRaw {
 			sourcesRaw := v.(map[string]any)
 
-			processors := []string{}
-			if v := sourcesRaw["processors"].([]any); v != nil {
-				for _, v := range v {
-					processors = append(processors, v.(string))
-				}
+			path := fmt.Sprintf("source.%d", i)
+			sourceConf, err := expandInlineComponent(sourcesRaw, path)
+			if err != nil {
+				return err
 			}
-
-			sourceConf := configuration.ResourceConfig{
-				Name:       sourcesRaw["name"].(string),
-				Processors: processors,
+			if err := expandProcessors(sourcesRaw, path, &sourceConf); err != nil {
+				return err
 			}
 			sources = append(sources, sourceConf)
 		}
@@ -271,19 +233,16 @@ func resourceConfigurationCreate(d *schema.ResourceData, meta any) error {
 	destinations := []configuration.ResourceConfig{}
 	if d.Get("destination") != nil {
 		destinationsRaw := d.Get("destination").([]any)
-		for _, v := range destinationsRaw {
+		for i, v := range destinationsRaw {
 			destinationRaw := v.(map[string]any)
 
-			processors := []string{}
-			if v := destinationRaw["processors"].([]any); v != nil {
-				for _, v := range v {
-					processors = append(processors, v.(string))
-				}
+			path := fmt.Sprintf("destination.%d", i)
+			destConfig, err := expandInlineComponent(destinationRaw, path)
+			if err != nil {
+				return err
 			}
-
-			destConfig := configuration.ResourceConfig{
-				Name:       destinationRaw["name"].(string),
-				Processors: processors,
+			if err := expandProcessors(destinationRaw, path, &destConfig); err != nil {
+				return err
 			}
 			destinations = append(destinations, destConfig)
 		}
@@ -371,6 +330,14 @@ func resourceConfigurationRead(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
+	if err := d.Set("version", int(config.Version())); err != nil {
+		return err
+	}
+
+	if err := setConfigurationVersions(d, config); err != nil {
+		return err
+	}
+
 	labels := config.Metadata.Labels.AsMap()
 	platform, ok := labels["platform"]
 	if ok {
@@ -395,30 +362,38 @@ func resourceConfigurationRead(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
+	if err := setSelector(d, config.Name(), matchLabels); err != nil {
+		return err
+	}
+
+	stateSourceBlocks := d.Get("source").([]any)
 	sourceBlocks := []map[string]any{}
-	for _, s := range config.Spec.Sources {
+	for i, s := range config.Spec.Sources {
 		source := map[string]any{}
-		source["name"] = strings.Split(s.Name, ":")[0]
-		processors := []string{}
-		for _, p := range s.Processors {
-			processors = append(processors, strings.Split(p.Name, ":")[0])
+		stateSource := stateBlock(stateSourceBlocks, i)
+		if err := flattenInlineComponent(s, stateSource, source); err != nil {
+			return err
+		}
+		if err := flattenProcessors(s.Processors, stateSource, source); err != nil {
+			return err
 		}
-		source["processors"] = processors
 		sourceBlocks = append(sourceBlocks, source)
 	}
 	if err := d.Set("source", sourceBlocks); err != nil {
 		return err
 	}
 
+	stateDestinationBlocks := d.Get("destination").([]any)
 	destinationBlocks := []map[string]any{}
-	for _, d := range config.Spec.Destinations {
+	for i, dest := range config.Spec.Destinations {
 		destination := map[string]any{}
-		destination["name"] = strings.Split(d.Name, ":")[0]
-		processors := []string{}
-		for _, p := range d.Processors {
-			processors = append(processors, strings.Split(p.Name, ":")[0])
+		stateDestination := stateBlock(stateDestinationBlocks, i)
+		if err := flattenInlineComponent(dest, stateDestination, destination); err != nil {
+			return err
+		}
+		if err := flattenProcessors(dest.Processors, stateDestination, destination); err != nil {
+			return err
 		}
-		destination["processors"] = processors
 		destinationBlocks = append(destinationBlocks, destination)
 	}
 	if err := d.Set("destination", destinationBlocks); err != nil {
@@ -449,37 +424,3 @@ func resourceConfigurationRead(d *schema.ResourceData, meta any) error {
 	d.SetId(config.ID())
 	return nil
 }
-
-// resourceConfigurationRolloutOptionsRead takes a configuration's rollout options
-// and sets them in the Terraform state. This will trigger a terraform apply if the
-// rollout options have changed outside of Terraform.
-func resourceConfigurationRolloutOptionsRead(d *schema.ResourceData, rollout model.ResourceConfiguration) error {
-	if len(rollout.Parameters) == 0 {
-		return nil
-	}
-
-	rolloutOptions := make(map[string]interface{})
-
-	rolloutOptions["type"] = rollout.Type
-
-	// Do not parse parameters when using standard rollout type
-	if rollout.Type == "standard" {
-		return nil
-	}
-
-	parameters := make([]interface{}, len(rollout.Parameters))
-	for i, param := range rollout.Parameters {
-		parameters[i] = map[string]interface{}{
-			"name":  param.Name,
-			"value": param.Value,
-		}
-	}
-
-	rolloutOptions["parameters"] = parameters
-
-	if err := d.Set("rollout_options", []interface{}{rolloutOptions}); err != nil {
-		return fmt.Errorf("error setting rollout options: %s", err)
-	}
-
-	return nil
-}
@@ -0,0 +1,166 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+// resourceConfigurationRollback re-applies a historical version of a
+// configuration and rolls it out. The rollback creates a new configuration
+// version, it does not remove the versions created after the historical
+// version.
+func resourceConfigurationRollback() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceConfigurationRollbackCreate,
+		Read:          resourceConfigurationRollbackRead,
+		DeleteContext: resourceConfigurationRollbackDelete,
+		Schema: map[string]*schema.Schema{
+			"configuration": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the configuration to roll back.",
+			},
+			"rollback_to_version": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAtLeast(1),
+				Description:  "The historical configuration version to re-apply and roll out.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The configuration version created by the rollback.",
+			},
+		},
+		CustomizeDiff: resourceCustomizeDiff("bindplane_configuration_rollback"),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(maxTimeout),
+			Read:   schema.DefaultTimeout(maxTimeout),
+			Delete: schema.DefaultTimeout(maxTimeout),
+		},
+	}
+}
+
+func resourceConfigurationRollbackCreate(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	name := d.Get("configuration").(string)
+	target := model.Version(d.Get("rollback_to_version").(int))
+
+	v, err := bindplane.ConfigurationVersion(name, target)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return fmt.Errorf("configuration %s version %d does not exist", name, target)
+	}
+
+	r, err := rollbackResource(v.Resource, target)
+	if err != nil {
+		return fmt.Errorf("roll back configuration %s to version %d: %w", name, target, err)
+	}
+
+	ctx := context.Background()
+	timeout := d.Timeout(schema.TimeoutCreate) - time.Minute
+	if err := bindplane.ApplyWithRetry(ctx, timeout, &r, false); err != nil {
+		return fmt.Errorf("apply configuration %s version %d: %w", name, target, err)
+	}
+
+	// Always roll out, the historical spec may be identical to
+	// the latest version, which was never rolled out.
+	if err := bindplane.Rollout(name); err != nil {
+		return fmt.Errorf("roll out configuration %s: %w", name, err)
+	}
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("configuration %s does not exist after rollback", name)
+	}
+
+	if err := d.Set("version", int(config.Version())); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", name, target))
+	return nil
+}
+
+func resourceConfigurationRollbackRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	config, err := bindplane.Configuration(d.Get("configuration").(string))
+	if err != nil {
+		return err
+	}
+
+	// The rollback cannot exist without its configuration
+	if config == nil {
+		d.SetId("")
+	}
+	return nil
+}
+
+// resourceConfigurationRollbackDelete removes the rollback from state.
+// The configuration is not modified.
+func resourceConfigurationRollbackDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// rollbackResource returns the resource to apply in order to restore a
+// historical configuration version. Fields set by Bindplane, such as the
+// version and modification time, are omitted. The restored version is
+// recorded with client.RollbackLabel.
+func rollbackResource(historical *model.AnyResource, version model.Version) (model.AnyResource, error) {
+	labelMap := map[string]string{}
+	for k, v := range historical.Metadata.Labels.AsMap() {
+		labelMap[k] = v
+	}
+	labelMap[client.RollbackLabel] = strconv.Itoa(int(version))
+
+	labels, err := model.LabelsFromMap(labelMap)
+	if err != nil {
+		return model.AnyResource{}, fmt.Errorf("labels: %w", err)
+	}
+
+	return model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: historical.APIVersion,
+			Kind:       historical.Kind,
+			Metadata: model.Metadata{
+				ID:          historical.Metadata.ID,
+				Name:        historical.Metadata.Name,
+				DisplayName: historical.Metadata.DisplayName,
+				Description: historical.Metadata.Description,
+				Labels:      labels,
+			},
+		},
+		Spec: historical.Spec,
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackResource(t *testing.T) {
+	modified := time.Date(2025, 2, 7, 14, 50, 54, 0, time.UTC)
+	labels, err := model.LabelsFromMap(map[string]string{"platform": "linux"})
+	require.NoError(t, err)
+
+	historical := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: "bindplane.observiq.com/v1",
+			Kind:       model.KindConfiguration,
+			Metadata: model.Metadata{
+				ID:           "01JKEX6ZZNHHNX171N8JKQC57M",
+				Name:         "my-config",
+				Labels:       labels,
+				Version:      3,
+				DateModified: &modified,
+			},
+		},
+		Spec: map[string]any{
+			"contentType": "text/yaml",
+		},
+	}
+
+	restored, err := model.LabelsFromMap(map[string]string{
+		"platform":           "linux",
+		client.RollbackLabel: "3",
+	})
+	require.NoError(t, err)
+
+	r, err := rollbackResource(historical, 3)
+	require.NoError(t, err)
+	require.Equal(t, model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			APIVersion: "bindplane.observiq.com/v1",
+			Kind:       model.KindConfiguration,
+			Metadata: model.Metadata{
+				ID:     "01JKEX6ZZNHHNX171N8JKQC57M",
+				Name:   "my-config",
+				Labels: restored,
+			},
+		},
+		Spec: map[string]any{
+			"contentType": "text/yaml",
+		},
+	}, r)
+
+	// The historical labels are not modified
+	require.NotContains(t, historical.Metadata.Labels.AsMap(), client.RollbackLabel)
+}
+
+func TestChangesOtherThanLabels(t *testing.T) {
+	require.False(t, changesOtherThanLabels(nil))
+	require.False(t, changesOtherThanLabels([]string{"labels.%", "labels.terraform-rollback-version"}))
+	require.True(t, changesOtherThanLabels([]string{"labels.%", "source.0.name"}))
+	require.True(t, changesOtherThanLabels([]string{"labels_extra"}))
+}
@@ -29,15 +29,19 @@ import (
 	"github.com/observiq/terraform-provider-bindplane/internal/resource"
 	v2 "github.com/observiq/terraform-provider-bindplane/provider/resource/configuration/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceConfigurationV2() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceConfigurationV2Create,
-		Update: resourceConfigurationV2Create, // Run create as update
-		Read:   resourceConfigurationV2Read,
-		Delete: genericConfigurationDelete,
+	return withStateUpgraders(&schema.Resource{
+		Create:        resourceConfigurationV2Create,
+		Update:        resourceConfigurationV2Create, // Run create as update
+		ReadContext:   readWithDriftWarnings(model.KindConfiguration, resourceConfigurationV2Read),
+		DeleteContext: genericConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConfigurationV2Import,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -76,8 +80,9 @@ func resourceConfigurationV2() *schema.Resource {
 				Type:        schema.TypeMap,
 				Computed:    true,
 				ForceNew:    false,
-				Description: "Labels that Bindplane uses to determine which agents the configuration should apply to. This value is computed by Terraform and is not user configurable.",
+				Description: "Labels that Bindplane uses to determine which agents the configuration should apply to. This value is computed from the selector block.",
 			},
+			"selector": selectorSchema,
 			"source": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -86,10 +91,12 @@ func resourceConfigurationV2() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							ForceNew:    false,
-							Description: "Name of the source to attach.",
+							Description: "Name of the source to attach. Set either name or type.",
 						},
+						"type":            inlineTypeSchema("source"),
+						"parameters_json": inlineParametersSchema("source"),
 						"processors": {
 							Type:        schema.TypeList,
 							Optional:    true,
@@ -97,10 +104,11 @@ func resourceConfigurationV2() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Description: "List of processor names to attach to the source.",
 						},
-						"route": v2.RouteSchema,
+						"processor": inlineProcessorSchema,
+						"route":     v2.RouteSchema,
 					},
 				},
-				Description: "Source name and list of processor names to attach to the configuration. This option can be configured one or many times.",
+				Description: "Source name or inline source type, and the processors to attach to the configuration. This option can be configured one or many times.",
 			},
 			"connector": {
 				Type:     schema.TypeList,
@@ -139,12 +147,13 @@ func resourceConfigurationV2() *schema.Resource {
 						},
 						"processors": {
 							Type:        schema.TypeList,
-							Required:    true,
+							Optional:    true,
 							ForceNew:    false,
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Description: "List of processor names to attach to the processor group.",
 						},
-						"route": v2.RouteSchema,
+						"processor": inlineProcessorSchema,
+						"route":     v2.RouteSchema,
 					},
 				},
 				Description: "Group of processors that will receive and process telemetry from one or more routes.",
@@ -163,10 +172,12 @@ func resourceConfigurationV2() *schema.Resource {
 						},
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							ForceNew:    false,
-							Description: "Name of the destination to attach.",
+							Description: "Name of the destination to attach. Set either name or type.",
 						},
+						"type":            inlineTypeSchema("destination"),
+						"parameters_json": inlineParametersSchema("destination"),
 						"processors": {
 							Type:        schema.TypeList,
 							Optional:    true,
@@ -174,10 +185,12 @@ func resourceConfigurationV2() *schema.Resource {
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Description: "List of processor names to attach to the destination.",
 						},
+						"processor": inlineProcessorSchema,
 					},
 				},
-				Description: "Destination name and list of processor names to attach to the configuration. This option can be configured one or many times.",
+				Description: "Destination name or inline destination type, and the processors to attach to the configuration. This option can be configured one or many times.",
 			},
+			"pipeline": v2.PipelineSchema,
 			"extensions": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -204,71 +217,29 @@ func resourceConfigurationV2() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
-			"rollout_options": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: false,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
-								t := val.(string)
-								if t != "standard" && t != "progressive" {
-									errs = append(errs, fmt.Errorf("invalid rollout type: %s", t))
-								}
-								return
-							},
-							ForceNew:    false,
-							Description: "The type of rollout to perform. Valid values are 'standard' and 'progressive'.",
-						},
-						"parameters": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Name of the parameter.",
-									},
-									"value": {
-										Type:     schema.TypeList,
-										Required: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"labels": {
-													Type:        schema.TypeMap,
-													Required:    true,
-													Description: "Labels for the parameter.",
-												},
-												"name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Name of the stage.",
-												},
-											},
-										},
-										Description: "Value of the parameter, which is a list of stages.",
-									},
-								},
-							},
-							Description: "List of parameters for the rollout options.",
-						},
-					},
-				},
-				Description: "Options for configuring the rollout behavior of the configuration.",
+			"rollout_options": rolloutOptionsSchema,
+			"advanced":        advancedSchema,
+			"version":         versionSchema,
+			"current_version": currentVersionSchema,
+			"pending_version": pendingVersionSchema,
+			"api_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Bindplane api version of the configuration. Configurations imported from bindplane_configuration have api version bindplane.observiq.com/v1 until the next apply upgrades them to bindplane.observiq.com/v2.",
 			},
-			"advanced": advancedSchema,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffUpgradeAPIVersion,
+			configurationCustomizeDiff("bindplane_configuration_v2"),
+			customizeDiffInlineComponents(configurationV2InlineBlocks),
+			customizeDiffRouteGraph,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
 			Delete: schema.DefaultTimeout(maxTimeout),
 		},
-	}
+	}, configurationV2StateUpgraders)
 }
 
 func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
@@ -277,6 +248,10 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindConfiguration, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -296,23 +271,27 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 	}
 	labels["platform"] = d.Get("platform").(string)
 
-	// Match labels should always be configuration=<name>
-	matchLabels := map[string]string{
-		"configuration": name,
+	// Match labels default to configuration=<name> when
+	// the selector block is not set.
+	matchLabels, err := configurationMatchLabels(d.Get, name)
+	if err != nil {
+		return err
 	}
 
 	// List of sources and their processors
 	sources := []configuration.ResourceConfig{}
 	if d.Get("source") != nil {
 		sourcesRaw := d.Get("source").([]any)
-		for _, v := range sourcesRaw {
+		for i, v := range sourcesRaw {
 			sourcesRaw := v.(map[string]any)
 
-			processors := []string{}
-			if v := sourcesRaw["processors"].([]any); v != nil {
-				for _, v := range v {
-					processors = append(processors, v.(string))
-				}
+			path := fmt.Sprintf("source.%d", i)
+			sourceConf, err := expandInlineComponent(sourcesRaw, path)
+			if err != nil {
+				return err
+			}
+			if err := expandProcessors(sourcesRaw, path, &sourceConf); err != nil {
+				return err
 			}
 
 			routes := &model.Routes{}
@@ -324,11 +303,7 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 				routes = r
 			}
 
-			sourceConf := configuration.ResourceConfig{
-				Name:       sourcesRaw["name"].(string),
-				Processors: processors,
-				Routes:     routes,
-			}
+			sourceConf.Routes = routes
 			sources = append(sources, sourceConf)
 		}
 	}
@@ -360,14 +335,14 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 	processorGroups := []configuration.ResourceConfig{}
 	if d.Get("processor_group") != nil {
 		processorGroupsRaw := d.Get("processor_group").([]any)
-		for _, v := range processorGroupsRaw {
+		for i, v := range processorGroupsRaw {
 			processorGroupRaw := v.(map[string]any)
 
-			processors := []string{}
-			if v := processorGroupRaw["processors"].([]any); v != nil {
-				for _, v := range v {
-					processors = append(processors, v.(string))
-				}
+			processorGroupConf := configuration.ResourceConfig{
+				RouteID: processorGroupRaw["route_id"].(string),
+			}
+			if err := expandProcessors(processorGroupRaw, fmt.Sprintf("processor_group.%d", i), &processorGroupConf); err != nil {
+				return err
 			}
 
 			routes := &model.Routes{}
@@ -379,11 +354,7 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 				routes = r
 			}
 
-			processorGroupConf := configuration.ResourceConfig{
-				RouteID:    processorGroupRaw["route_id"].(string),
-				Processors: processors,
-				Routes:     routes,
-			}
+			processorGroupConf.Routes = routes
 			processorGroups = append(processorGroups, processorGroupConf)
 		}
 	}
@@ -392,25 +363,33 @@ func resourceConfigurationV2Create(d *schema.ResourceData, meta any) error {
 	destinations := []configuration.ResourceConfig{}
 	if d.Get("destination") != nil {
 		destinationsRaw := d.Get("destination").([]any)
-		for _, v := range destinationsRaw {
+		for i, v := range destinationsRaw {
 			destinationRaw := v.(map[string]any)
 
-			processors := []string{}
-			if v := destinationRaw["processors"].([]any); v != nil {
-				for _, v := range v {
-					processors = append(processors, v.(string))
-				}
+			path := fmt.Sprintf("destination.%d", i)
+			destConfig, err := expandInlineComponent(destinationRaw, path)
+			if err != nil {
+				return err
 			}
-
-			destConfig := configuration.ResourceConfig{
-				RouteID:    destinationRaw["route_id"].(string),
-				Name:       destinationRaw["name"].(string),
-				Processors: processors,
+			if err := expandProcessors(destinationRaw, path, &destConfig); err != nil {
+				return err
 			}
+			destConfig.RouteID = destinationRaw["route_id"].(string)
 			destinations = append(destinations, destConfig)
 		}
 	}
 
+	// Pipelines are expanded into sources, processor groups, and
+	// destinations, which are added after the components defined
+	// by blocks.
+	pipelines, err := expandPipelines(d.Get("pipeline").([]any), sources, processorGroups, destinations)
+	if err != nil {
+		return err
+	}
+	sources = append(sources, pipelineResourceConfigs(pipelines.Sources)...)
+	processorGroups = append(processorGroups, pipelineResourceConfigs(pipelines.Processors)...)
+	destinations = append(destinations, pipelineResourceConfigs(pipelines.Destinations)...)
+
 	rolloutOptions, err := readRolloutOptions(d)
 	if err != nil {
 		return fmt.Errorf("read rollout_options: %w", err)
@@ -495,6 +474,18 @@ func resourceConfigurationV2Read(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
+	if err := d.Set("version", int(config.Version())); err != nil {
+		return err
+	}
+
+	if err := setConfigurationVersions(d, config); err != nil {
+		return err
+	}
+
+	if err := d.Set("api_version", config.APIVersion); err != nil {
+		return err
+	}
+
 	labels := config.Metadata.Labels.AsMap()
 	platform, ok := labels["platform"]
 	if ok {
@@ -519,15 +510,47 @@ func resourceConfigurationV2Read(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
+	if err := setSelector(d, config.Name(), matchLabels); err != nil {
+		return err
+	}
+
+	// Components generated for the pipelines in state are collapsed
+	// back into pipelines. Pipelines which no longer match the
+	// configuration are removed, and their components are read
+	// as blocks instead.
+	components := component.PipelineComponents{
+		Sources:      config.Spec.Sources,
+		Processors:   config.Spec.Processors,
+		Destinations: config.Spec.Destinations,
+	}
+
+	// v1 configurations, such as those imported from bindplane_configuration,
+	// are read as the equivalent v2 configuration.
+	if config.APIVersion == configurationAPIVersionV1 {
+		components.Sources, components.Destinations = component.UpgradeV1Components(
+			components.Sources, components.Destinations, componentTelemetryTypes(bindplane))
+	}
+	statePipelines, _ := readPipelines(d.Get("pipeline").([]any))
+	pipelines := []component.Pipeline{}
+	if remaining, ok := component.CollapsePipelines(statePipelines, components); ok {
+		components = remaining
+		pipelines = statePipelines
+	}
+	if err := d.Set("pipeline", flattenPipelines(pipelines)); err != nil {
+		return err
+	}
+
+	stateSourceBlocks := d.Get("source").([]any)
 	sourceBlocks := []map[string]any{}
-	for _, s := range config.Spec.Sources {
+	for i, s := range components.Sources {
 		source := map[string]any{}
-		source["name"] = strings.Split(s.Name, ":")[0]
-		processors := []string{}
-		for _, p := range s.Processors {
-			processors = append(processors, strings.Split(p.Name, ":")[0])
+		stateSource := stateBlock(stateSourceBlocks, i)
+		if err := flattenInlineComponent(s, stateSource, source); err != nil {
+			return err
+		}
+		if err := flattenProcessors(s.Processors, stateSource, source); err != nil {
+			return err
 		}
-		source["processors"] = processors
 
 		stateRoutes, err := component.RoutesToState(s.Routes)
 		if err != nil {
@@ -559,19 +582,21 @@ func resourceConfigurationV2Read(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
-	// Save the current state here so we can retrieve the saved
-	// route ID
+	// Route IDs are read from the IDs returned by Bindplane. The state
+	// blocks are used to preserve sensitive values and the processor
+	// block style.
 	stateProcessorGroupBlocks := d.Get("processor_group").([]any)
 
 	processorGroupBlocks := []map[string]any{}
-	for _, pg := range config.Spec.Processors {
-		processorGroup := map[string]any{}
+	for i, pg := range components.Processors {
+		stateProcessorGroup := stateBlockByRouteID(stateProcessorGroupBlocks, pg.ID, i)
+		processorGroup := map[string]any{
+			"route_id": routeID(pg, stateProcessorGroup),
+		}
 
-		processors := []string{}
-		for _, p := range pg.Processors {
-			processors = append(processors, strings.Split(p.Name, ":")[0])
+		if err := flattenProcessors(pg.Processors, stateProcessorGroup, processorGroup); err != nil {
+			return err
 		}
-		processorGroup["processors"] = processors
 
 		stateRoutes, err := component.RoutesToState(pg.Routes)
 		if err != nil {
@@ -579,44 +604,27 @@ func resourceConfigurationV2Read(d *schema.ResourceData, meta any) error {
 		}
 		processorGroup["route"] = stateRoutes
 
-		// Retrieve the saved route IDs from state and copy them
-		// to the new processor blocks before calling d.Set.
-		for _, stateProcessorGroup := range stateProcessorGroupBlocks {
-			stateProcessorGroup := stateProcessorGroup.(map[string]any)
-			if stateProcessorGroup["route_id"] == pg.ID {
-				processorGroup["route_id"] = stateProcessorGroup["route_id"]
-				break
-			}
-		}
-
 		processorGroupBlocks = append(processorGroupBlocks, processorGroup)
 	}
 	if err := d.Set("processor_group", processorGroupBlocks); err != nil {
 		return err
 	}
 
-	// Save the current state here so we can retrieve the saved
-	// route ID
+	// Destinations are matched to their state block by route ID, so
+	// the same destination can be attached more than once.
 	stateDestinationBlocks := d.Get("destination").([]any)
 
 	destinationBlocks := []map[string]any{}
-	for _, d := range config.Spec.Destinations {
-		destination := map[string]any{}
-		destination["name"] = strings.Split(d.Name, ":")[0]
-		processors := []string{}
-		for _, p := range d.Processors {
-			processors = append(processors, strings.Split(p.Name, ":")[0])
+	for i, dest := range components.Destinations {
+		stateDestination := stateBlockByRouteID(stateDestinationBlocks, dest.ID, i)
+		destination := map[string]any{
+			"route_id": routeID(dest, stateDestination),
 		}
-		destination["processors"] = processors
-
-		// Retrieve the saved route IDs from state and copy them
-		// to the new destination blocks before calling d.Set.
-		for _, stateDestination := range stateDestinationBlocks {
-			stateDestination := stateDestination.(map[string]any)
-			if stateDestination["name"] == destination["name"] {
-				destination["route_id"] = stateDestination["route_id"]
-				break
-			}
+		if err := flattenInlineComponent(dest, stateDestination, destination); err != nil {
+			return err
+		}
+		if err := flattenProcessors(dest.Processors, stateDestination, destination); err != nil {
+			return err
 		}
 
 		destinationBlocks = append(destinationBlocks, destination)
@@ -0,0 +1,234 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+)
+
+// configurationAPIVersionV1 is the api version of configurations
+// managed by bindplane_configuration.
+const configurationAPIVersionV1 = "bindplane.observiq.com/v1"
+
+// stateBlockByRouteID returns the block saved to state with the route ID.
+// Blocks saved without a route ID are matched by their position i instead.
+// Returns nil if there is no such block.
+func stateBlockByRouteID(blocks []any, routeID string, i int) map[string]any {
+	if routeID != "" {
+		for _, b := range blocks {
+			if b, _ := b.(map[string]any); b != nil && b["route_id"] == routeID {
+				return b
+			}
+		}
+	}
+	if b := stateBlock(blocks, i); b != nil {
+		if id, _ := b["route_id"].(string); id == "" {
+			return b
+		}
+	}
+	return nil
+}
+
+// routeID returns the route ID of a component returned by Bindplane. The
+// route ID saved to state is used if Bindplane did not return one.
+func routeID(c model.ResourceConfiguration, state map[string]any) string {
+	if c.ID != "" {
+		return c.ID
+	}
+	id, _ := state["route_id"].(string)
+	return id
+}
+
+// resourceConfigurationV2StateUpgradeV0 repairs processor group and
+// destination route IDs saved by version 0, which matched destinations by
+// name and could save the same route ID to several blocks. Duplicate route
+// IDs are cleared, the following read matches blocks without a route ID to
+// the component at the same position and saves the ID Bindplane returns.
+func resourceConfigurationV2StateUpgradeV0(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for _, key := range []string{"processor_group", "destination"} {
+		blocks, _ := rawState[key].([]any)
+		clearDuplicateRouteIDs(blocks)
+	}
+	return rawState, nil
+}
+
+// clearDuplicateRouteIDs clears the route ID of blocks
+// with a route ID used by a previous block.
+func clearDuplicateRouteIDs(blocks []any) {
+	seen := map[string]bool{}
+	for _, b := range blocks {
+		block, _ := b.(map[string]any)
+		id, _ := block["route_id"].(string)
+		if id == "" {
+			continue
+		}
+		if seen[id] {
+			block["route_id"] = ""
+		}
+		seen[id] = true
+	}
+}
+
+// resourceConfigurationV2Import imports a configuration by name. v1
+// configurations can be imported, which allows a bindplane_configuration
+// to be managed by bindplane_configuration_v2 without creating a new
+// configuration. The next apply upgrades the configuration to v2.
+func resourceConfigurationV2Import(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	bindplane := meta.(*client.BindPlane)
+	name := d.Id()
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%s with name '%s' does not exist", model.KindConfiguration, name)
+	}
+	if config.Spec.Raw != "" {
+		return nil, fmt.Errorf("configuration '%s' is a raw configuration, import it with bindplane_raw_configuration", name)
+	}
+
+	d.SetId(config.ID())
+	if err := d.Set("name", config.Name()); err != nil {
+		return nil, fmt.Errorf("failed to set resource name in state for imported %s '%s': %v", model.KindConfiguration, name, err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// configurationV1StateToV2 converts the raw state of a bindplane_configuration
+// to the raw state of the equivalent bindplane_configuration_v2. Sources and
+// destinations are given the routes and route IDs of the v2 configuration,
+// the same as when a v1 configuration is read. The other attributes are the
+// same in both resources and are kept.
+func configurationV1StateToV2(state map[string]any, telemetryTypes func(c model.ResourceConfiguration, destination bool) []string) (map[string]any, error) {
+	sourceBlocks, err := rawStateBlocks(state, "source")
+	if err != nil {
+		return nil, err
+	}
+	destinationBlocks, err := rawStateBlocks(state, "destination")
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []model.ResourceConfiguration{}
+	for _, b := range sourceBlocks {
+		sources = append(sources, rawStateComponent(b))
+	}
+	destinations := []model.ResourceConfiguration{}
+	for _, b := range destinationBlocks {
+		destinations = append(destinations, rawStateComponent(b))
+	}
+	sources, destinations = component.UpgradeV1Components(sources, destinations, telemetryTypes)
+
+	upgraded := maps.Clone(state)
+	upgradedSources := []any{}
+	for i, s := range sources {
+		routes, err := component.RoutesToState(s.Routes)
+		if err != nil {
+			return nil, fmt.Errorf("routes to state: %w", err)
+		}
+		source := maps.Clone(sourceBlocks[i])
+		source["route"] = routes
+		upgradedSources = append(upgradedSources, source)
+	}
+	upgradedDestinations := []any{}
+	for i, d := range destinations {
+		destination := maps.Clone(destinationBlocks[i])
+		destination["route_id"] = d.ID
+		upgradedDestinations = append(upgradedDestinations, destination)
+	}
+	upgraded["source"] = upgradedSources
+	upgraded["destination"] = upgradedDestinations
+	upgraded["api_version"] = configurationAPIVersionV1
+	return upgraded, nil
+}
+
+// rawStateBlocks returns the blocks of the list attribute key of a raw state
+func rawStateBlocks(state map[string]any, key string) ([]map[string]any, error) {
+	raw, _ := state[key].([]any)
+	blocks := []map[string]any{}
+	for i, v := range raw {
+		block, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s.%d: expected a block, got %T", key, i, v)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// rawStateComponent returns the name and type of
+// a source or destination block of a raw state.
+func rawStateComponent(block map[string]any) model.ResourceConfiguration {
+	name, _ := block["name"].(string)
+	rType, _ := block["type"].(string)
+	return model.ResourceConfiguration{
+		Name:              name,
+		ParameterizedSpec: model.ParameterizedSpec{Type: rType},
+	}
+}
+
+// customizeDiffUpgradeAPIVersion plans an update of configurations which
+// are not yet v2, so that the next apply upgrades them in place.
+func customizeDiffUpgradeAPIVersion(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if apiVersion, _ := d.Get("api_version").(string); apiVersion == configurationAPIVersionV1 {
+		return d.SetNewComputed("api_version")
+	}
+	return nil
+}
+
+// componentTelemetryTypes returns a function which returns the telemetry
+// types supported by a source or destination, or nil if they cannot be
+// found. Lookups are cached.
+func componentTelemetryTypes(bindplane *client.BindPlane) func(c model.ResourceConfiguration, destination bool) []string {
+	cache := map[string][]string{}
+	return func(c model.ResourceConfiguration, destination bool) []string {
+		kind, typeKind := model.KindSource, model.KindSourceType
+		if destination {
+			kind, typeKind = model.KindDestination, model.KindDestinationType
+		}
+
+		rType := strings.Split(c.Type, ":")[0]
+		if name := strings.Split(c.Name, ":")[0]; name != "" {
+			rType = componentType(bindplane.AnyResource, kind, name)
+		}
+		if rType == "" {
+			return nil
+		}
+
+		key := fmt.Sprintf("%s/%s", typeKind, rType)
+		if types, ok := cache[key]; ok {
+			return types
+		}
+		types := telemetryTypes(bindplane.AnyResource, typeKind, rType)
+		cache[key] = types
+		return types
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateBlockByRouteID(t *testing.T) {
+	blocks := []any{
+		map[string]any{"route_id": "google-a", "name": "google"},
+		map[string]any{"route_id": "google-b", "name": "google"},
+		map[string]any{"route_id": "", "name": "otlp"},
+	}
+
+	require.Equal(t, blocks[1], stateBlockByRouteID(blocks, "google-b", 0))
+	require.Equal(t, blocks[0], stateBlockByRouteID(blocks, "google-a", 1))
+
+	// Blocks without a route ID are matched by position
+	require.Equal(t, blocks[2], stateBlockByRouteID(blocks, "otlp", 2))
+
+	// Blocks with a different route ID are not matched by position
+	require.Nil(t, stateBlockByRouteID(blocks, "new", 0))
+	require.Nil(t, stateBlockByRouteID(blocks, "new", 3))
+}
+
+func TestRouteID(t *testing.T) {
+	state := map[string]any{"route_id": "saved"}
+	require.Equal(t, "server", routeID(model.ResourceConfiguration{ID: "server"}, state))
+	require.Equal(t, "saved", routeID(model.ResourceConfiguration{}, state))
+	require.Equal(t, "", routeID(model.ResourceConfiguration{}, nil))
+}
+
+func TestResourceConfigurationV2StateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name   string
+		state  map[string]any
+		expect map[string]any
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"valid",
+			map[string]any{
+				"name": "my-config",
+				"destination": []any{
+					map[string]any{"route_id": "google-a", "name": "google"},
+					map[string]any{"route_id": "google-b", "name": "google"},
+				},
+			},
+			map[string]any{
+				"name": "my-config",
+				"destination": []any{
+					map[string]any{"route_id": "google-a", "name": "google"},
+					map[string]any{"route_id": "google-b", "name": "google"},
+				},
+			},
+		},
+		{
+			"duplicate",
+			map[string]any{
+				"name": "my-config",
+				"processor_group": []any{
+					map[string]any{"route_id": "batch"},
+					map[string]any{"route_id": "batch"},
+				},
+				"destination": []any{
+					map[string]any{"route_id": "google", "name": "google"},
+					map[string]any{"route_id": "", "name": "otlp"},
+					map[string]any{"route_id": "google", "name": "google"},
+					map[string]any{"route_id": "google", "name": "google"},
+				},
+			},
+			map[string]any{
+				"name": "my-config",
+				"processor_group": []any{
+					map[string]any{"route_id": "batch"},
+					map[string]any{"route_id": ""},
+				},
+				"destination": []any{
+					map[string]any{"route_id": "google", "name": "google"},
+					map[string]any{"route_id": "", "name": "otlp"},
+					map[string]any{"route_id": "", "name": "google"},
+					map[string]any{"route_id": "", "name": "google"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			upgraded, err := resourceConfigurationV2StateUpgradeV0(context.Background(), tc.state, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, upgraded)
+		})
+	}
+
+	// Read matches the cleared blocks by position
+	state, err := resourceConfigurationV2StateUpgradeV0(context.Background(), map[string]any{
+		"destination": []any{
+			map[string]any{"route_id": "google", "name": "google"},
+			map[string]any{"route_id": "google", "name": "google"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	blocks := state["destination"].([]any)
+	require.Equal(t, blocks[0], stateBlockByRouteID(blocks, "google", 0))
+	require.Equal(t, blocks[1], stateBlockByRouteID(blocks, "google-2", 1))
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
@@ -29,10 +30,10 @@ import (
 
 func resourceConnector() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConnectorCreate,
-		Update: resourceConnectorCreate,
-		Read:   resourceConnectorRead,
-		Delete: resourceConnectorDelete,
+		Create:        resourceConnectorCreate,
+		Update:        resourceConnectorCreate,
+		ReadContext:   readWithDriftWarnings(model.KindConnector, resourceConnectorRead),
+		DeleteContext: resourceConnectorDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceConnectorImportState,
 		},
@@ -62,7 +63,9 @@ func resourceConnector() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
+			"version": versionSchema,
 		},
+		CustomizeDiff: resourceCustomizeDiff("bindplane_connector"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -78,6 +81,10 @@ func resourceConnectorCreate(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindConnector, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -124,8 +131,8 @@ func resourceConnectorRead(d *schema.ResourceData, meta any) error {
 	return genericResourceRead(model.KindConnector, d, meta)
 }
 
-func resourceConnectorDelete(d *schema.ResourceData, meta any) error {
-	return genericResourceDelete(model.KindConnector, d, meta)
+func resourceConnectorDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return genericResourceDelete(ctx, model.KindConnector, d, meta)
 }
 
 func resourceConnectorImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
@@ -29,10 +30,10 @@ import (
 
 func resourceDestination() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDestinationCreate,
-		Update: resourceDestinationCreate,
-		Read:   resourceDestinationRead,
-		Delete: resourceDestinationDelete,
+		Create:        resourceDestinationCreate,
+		Update:        resourceDestinationCreate,
+		ReadContext:   readWithDriftWarnings(model.KindDestination, resourceDestinationRead),
+		DeleteContext: resourceDestinationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceDestinationImportState,
 		},
@@ -62,7 +63,9 @@ func resourceDestination() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
+			"version": versionSchema,
 		},
+		CustomizeDiff: resourceCustomizeDiff("bindplane_destination"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -78,6 +81,10 @@ func resourceDestinationCreate(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindDestination, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -124,8 +131,8 @@ func resourceDestinationRead(d *schema.ResourceData, meta any) error {
 	return genericResourceRead(model.KindDestination, d, meta)
 }
 
-func resourceDestinationDelete(d *schema.ResourceData, meta any) error {
-	return genericResourceDelete(model.KindDestination, d, meta)
+func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return genericResourceDelete(ctx, model.KindDestination, d, meta)
 }
 
 func resourceDestinationImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
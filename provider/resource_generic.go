@@ -15,16 +15,55 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
 	"github.com/observiq/terraform-provider-bindplane/internal/parameter"
 )
 
+// versionSchema is the Bindplane version of a resource. It is used
+// to detect changes made outside of Terraform before applying.
+var versionSchema = &schema.Schema{
+	Type:        schema.TypeInt,
+	Computed:    true,
+	Description: "The Bindplane version of the resource, as of the last time Terraform read it. Applying fails if the resource was modified in Bindplane since it was last read, unless the provider option last_writer_wins is enabled.",
+}
+
+// customizeDiffVersion marks version as unknown when the resource
+// will be updated, because Bindplane increments the version on
+// every change.
+func customizeDiffVersion(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	return d.SetNewComputed("version")
+}
+
+// checkResourceVersion returns an error if the resource was modified
+// in Bindplane since Terraform last read it. New resources are not
+// checked.
+func checkResourceVersion(bindplane *client.BindPlane, rKind model.Kind, name string, d *schema.ResourceData) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// The previous value is the version saved to state by the
+	// last read. The new value is unknown during updates.
+	version, _ := d.GetChange("version")
+	return bindplane.CheckVersion(rKind, name, model.Version(version.(int)))
+}
+
 // genericResourceRead can read source, destination, and processors
 // from the BindPlane API and set them.
 func genericResourceRead(rKind model.Kind, d *schema.ResourceData, meta any) error {
@@ -60,6 +99,10 @@ func genericResourceRead(rKind model.Kind, d *schema.ResourceData, meta any) err
 		return err
 	}
 
+	if err := d.Set("version", int(g.Version)); err != nil {
+		return err
+	}
+
 	rType := strings.Split(g.Spec.Type, ":")[0]
 	if err := d.Set("type", rType); err != nil {
 		return err
@@ -73,28 +116,9 @@ func genericResourceRead(rKind model.Kind, d *schema.ResourceData, meta any) err
 		}
 	}
 
-	// Parameters returned by BindPlane API
-	incomingParams := g.Spec.Parameters
-
 	// Update all sensitive parameters with the values from state
 	// instead of saving "(sensitive value)" to state.
-	for i, incomingParam := range incomingParams {
-		if incomingParam.Sensitive {
-			for _, stateParam := range stateParams {
-				if stateParam.Name == incomingParams[i].Name {
-					// Set the value to the value provided by the user in order to
-					// prevent terraform from attempting to update the value.
-					incomingParams[i].Value = stateParam.Value
-
-					// Preserve the sensitive value to whatever the user configured, which
-					// could be true, false, or nothing.
-					incomingParams[i].Sensitive = stateParam.Sensitive
-
-					break
-				}
-			}
-		}
-	}
+	incomingParams := parameter.MergeSensitive(g.Spec.Parameters, stateParams)
 
 	paramStr, err := parameter.ParametersToString(incomingParams)
 	if err != nil {
@@ -105,11 +129,110 @@ func genericResourceRead(rKind model.Kind, d *schema.ResourceData, meta any) err
 }
 
 // genericResourceDelete can delete configurations, sources,
-// destinations, and processors from the BindPlane API.
-func genericResourceDelete(rKind model.Kind, d *schema.ResourceData, meta any) error {
+// destinations, and processors from the BindPlane API. Deletes
+// rejected because of dependent resources are retried until the
+// delete timeout is reached.
+func genericResourceDelete(ctx context.Context, rKind model.Kind, d *schema.ResourceData, meta any) diag.Diagnostics {
 	bindplane := meta.(*client.BindPlane)
 	name := d.Get("name").(string)
-	return bindplane.Delete(rKind, name)
+
+	timeout := d.Timeout(schema.TimeoutDelete) - time.Minute
+	err := bindplane.DeleteWithRetry(ctx, timeout, rKind, name)
+	if err == nil {
+		return nil
+	}
+
+	var depErr *client.DependentResourcesError
+	if errors.As(err, &depErr) {
+		return dependentResourcesDiagnostics(bindplane, depErr)
+	}
+	return diag.FromErr(err)
+}
+
+// dependentResourcesDiagnostics returns an error diagnostic naming each
+// resource that still references a resource which could not be deleted,
+// along with the Terraform resource type that manages it. Terraform does
+// not give providers the addresses of other resources, so dependents are
+// identified by their type and name instead of their Terraform address.
+func dependentResourcesDiagnostics(bindplane *client.BindPlane, depErr *client.DependentResourcesError) diag.Diagnostics {
+	dependents := []string{}
+	for _, dep := range depErr.Dependents {
+		// Configurations can be managed by either configuration resource
+		// depending on their api version.
+		apiVersion := ""
+		if dep.Kind == model.KindConfiguration {
+			if c, err := bindplane.Configuration(dep.Name); err == nil && c != nil {
+				apiVersion = c.APIVersion
+			}
+		}
+
+		line := fmt.Sprintf("  - %s %q", dep.Kind, dep.Name)
+		if rType := terraformResourceType(dep.Kind, apiVersion); rType != "" {
+			line = fmt.Sprintf("%s, managed by %s with name = %q", line, rType, dep.Name)
+		}
+		dependents = append(dependents, line)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %q is still referenced by other resources", depErr.Kind, depErr.Name),
+			Detail: fmt.Sprintf(
+				"Bindplane refused to delete the %s because the following resources still reference it:\n\n%s\n\n"+
+					"The provider cannot know the Terraform address of a dependent resource. To find it, list the "+
+					"resources of its type with terraform state list | grep <type>, and match the name with "+
+					"terraform state show <address>.\n\n"+
+					"Remove the reference from the dependent resources and apply again, or set the lifecycle "+
+					"meta-argument create_before_destroy = true on the dependent resources so that Terraform "+
+					"updates them before deleting this %s.\n\nBindplane error: %s",
+				strings.ToLower(string(depErr.Kind)),
+				strings.Join(dependents, "\n"),
+				strings.ToLower(string(depErr.Kind)),
+				depErr.Error(),
+			),
+		},
+	}
+}
+
+// terraformResourceType returns the Terraform resource type used to manage
+// a Bindplane resource of the given kind. Returns an empty string if the kind
+// is not managed by this provider. apiVersion is only used for configurations,
+// and can be empty if it is not known.
+func terraformResourceType(kind model.Kind, apiVersion string) string {
+	switch kind {
+	case model.KindConfiguration:
+		switch apiVersion {
+		case configurationAPIVersionV1:
+			return "bindplane_configuration"
+		case "":
+			return "bindplane_configuration or bindplane_configuration_v2"
+		default:
+			return "bindplane_configuration_v2"
+		}
+	case model.KindSource:
+		return "bindplane_source"
+	case model.KindDestination:
+		return "bindplane_destination"
+	case model.KindProcessor:
+		// Processors referenced by other resources are processor bundles
+		return "bindplane_processor_bundle"
+	case model.KindExtension:
+		return "bindplane_extension"
+	case model.KindConnector:
+		return "bindplane_connector"
+	case model.KindSourceType:
+		return "bindplane_source_type"
+	case model.KindDestinationType:
+		return "bindplane_destination_type"
+	case model.KindProcessorType:
+		return "bindplane_processor_type"
+	case model.KindExtensionType:
+		return "bindplane_extension_type"
+	case model.KindConnectorType:
+		return "bindplane_connector_type"
+	default:
+		return ""
+	}
 }
 
 // genericResourceImport imports a BindPlane resource by looking it up
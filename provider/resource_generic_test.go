@@ -0,0 +1,81 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformResourceType(t *testing.T) {
+	cases := []struct {
+		kind       model.Kind
+		apiVersion string
+		expect     string
+	}{
+		{model.KindConfiguration, "bindplane.observiq.com/v1", "bindplane_configuration"},
+		{model.KindConfiguration, "bindplane.observiq.com/v2", "bindplane_configuration_v2"},
+		{model.KindConfiguration, "", "bindplane_configuration or bindplane_configuration_v2"},
+		{model.KindSource, "", "bindplane_source"},
+		{model.KindDestination, "", "bindplane_destination"},
+		{model.KindProcessor, "", "bindplane_processor_bundle"},
+		{model.KindExtension, "", "bindplane_extension"},
+		{model.KindConnector, "", "bindplane_connector"},
+		{model.KindSourceType, "", "bindplane_source_type"},
+		{model.KindConnectorType, "", "bindplane_connector_type"},
+		{model.KindAgent, "", ""},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.expect, terraformResourceType(tc.kind, tc.apiVersion))
+	}
+}
+
+func TestDependentResourcesDiagnostics(t *testing.T) {
+	depErr := &client.DependentResourcesError{
+		Kind: model.KindProcessor,
+		Name: "batch",
+		Dependents: []client.Dependent{
+			{Kind: model.KindProcessor, Name: "my-bundle"},
+		},
+		Err: errors.New("Dependent resources:\nProcessor my-bundle"),
+	}
+
+	diags := dependentResourcesDiagnostics(&client.BindPlane{}, depErr)
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Error, diags[0].Severity)
+	require.Equal(t, `Processor "batch" is still referenced by other resources`, diags[0].Summary)
+	require.Contains(t, diags[0].Detail, `- Processor "my-bundle", managed by bindplane_processor_bundle with name = "my-bundle"`)
+	require.Contains(t, diags[0].Detail, "The provider cannot know the Terraform address of a dependent resource.")
+}
+
+// Deletes rejected because of dependent resources are retried until the
+// delete timeout, which must be set so the retry window is the same for
+// every resource.
+func TestResourcesDeleteTimeout(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if r.DeleteContext == nil && r.Delete == nil {
+			continue
+		}
+		require.NotNil(t, r.Timeouts, name)
+		require.NotNil(t, r.Timeouts.Delete, name)
+		require.Equal(t, maxTimeout, *r.Timeouts.Delete, name)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/manifest"
+)
+
+// resourceManifest manages any Bindplane resource using its manifest,
+// for resource kinds which do not have a dedicated Terraform resource.
+func resourceManifest() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceManifestCreate,
+		Update:        resourceManifestCreate,
+		ReadContext:   resourceManifestReadContext,
+		DeleteContext: resourceManifestDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceManifestImportState,
+		},
+		Schema: map[string]*schema.Schema{
+			"manifest": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The resource's YAML or JSON manifest with apiVersion, kind, metadata, and spec fields.",
+				ValidateFunc:     validateManifest,
+				DiffSuppressFunc: suppressEquivalentManifestDiffs,
+			},
+			"rollout": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. Only applies to Configuration manifests.",
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The manifest's kind.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The manifest's metadata.name.",
+			},
+			"version": versionSchema,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffManifest,
+			resourceCustomizeDiff("bindplane_resource"),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(maxTimeout),
+			Read:   schema.DefaultTimeout(maxTimeout),
+			Delete: schema.DefaultTimeout(maxTimeout),
+		},
+	}
+}
+
+func resourceManifestCreate(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	m, err := manifest.Parse(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+	rKind, name := m.Kind(), m.Name()
+
+	if err := checkResourceVersion(bindplane, rKind, name, d); err != nil {
+		return err
+	}
+
+	// If id is unset, it means Terraform has not previously created
+	// this resource. Check to ensure a resource with this kind and
+	// name does not already exist.
+	if d.Id() == "" {
+		r, err := bindplane.AnyResource(rKind, name)
+		if err != nil {
+			return err
+		}
+		if r != nil {
+			return fmt.Errorf("%s with name '%s' already exists with id '%s'", rKind, name, r.ID())
+		}
+	}
+
+	r, err := m.AnyResource()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	timeout := d.Timeout(schema.TimeoutCreate) - time.Minute
+	if err := bindplane.ApplyWithRetry(ctx, timeout, r, d.Get("rollout").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(manifestID(rKind, name))
+
+	return resourceManifestRead(d, meta)
+}
+
+func resourceManifestReadContext(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	rKind, _, err := parseManifestID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return readWithDriftWarnings(rKind, resourceManifestRead)(ctx, d, meta)
+}
+
+func resourceManifestRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	rKind, name, err := parseManifestID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	r, err := bindplane.AnyResource(rKind, name)
+	if err != nil {
+		return err
+	}
+
+	// A nil return from AnyResource indicates that the resource
+	// did not exist. Terraform read operations should always set the
+	// ID to "" and return a nil error. This will allow Terraform to
+	// re-create the resource or confirm that it was deleted.
+	if r == nil {
+		d.SetId("")
+		return nil
+	}
+
+	read, err := manifest.FromAnyResource(r)
+	if err != nil {
+		return err
+	}
+
+	// Keep the manifest in state as written when Bindplane's copy
+	// contains everything it sets. Fields defaulted by Bindplane
+	// should not cause a diff. Otherwise, save Bindplane's copy so
+	// the difference is shown in the plan.
+	current, err := manifest.Parse(d.Get("manifest").(string))
+	if err != nil || !manifest.Contains(read, current) {
+		if err := d.Set("manifest", read.String()); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("kind", string(r.Kind)); err != nil {
+		return err
+	}
+
+	if err := d.Set("name", r.Name()); err != nil {
+		return err
+	}
+
+	if err := d.Set("version", int(r.Version())); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceManifestDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	rKind, _, err := parseManifestID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return genericResourceDelete(ctx, rKind, d, meta)
+}
+
+// resourceManifestImportState imports a Bindplane resource
+// by its kind and name, in the form kind/name.
+func resourceManifestImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	bindplane := meta.(*client.BindPlane)
+
+	rKind, name, err := parseManifestID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := bindplane.AnyResource(rKind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// bindplane.AnyResource will return a nil error if the resource
+	// does not exist. It is up to the caller to check.
+	if r == nil {
+		return nil, fmt.Errorf("%s with name '%s' does not exist", rKind, name)
+	}
+
+	// Use the kind as returned by Bindplane, the import ID
+	// may not match its case.
+	d.SetId(manifestID(r.Kind, r.Name()))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// manifestTypeKinds are the kinds of the component types
+// referenced by the spec.type of component manifests.
+var manifestTypeKinds = map[model.Kind]model.Kind{
+	model.KindSource:      model.KindSourceType,
+	model.KindDestination: model.KindDestinationType,
+	model.KindProcessor:   model.KindProcessorType,
+	model.KindExtension:   model.KindExtensionType,
+	model.KindConnector:   model.KindConnectorType,
+}
+
+// customizeDiffManifest sets the kind and name during plan, and
+// replaces the resource when the manifest's kind or name changes.
+// Component manifests must reference a component type which exists,
+// and new manifests must not conflict with an existing resource.
+func customizeDiffManifest(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	// The manifest is unknown when it depends on
+	// resources which have not been created.
+	if !d.NewValueKnown("manifest") {
+		return nil
+	}
+
+	m, err := manifest.Parse(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+
+	if d.Id() != "" {
+		rKind, name, err := parseManifestID(d.Id())
+		if err != nil {
+			return err
+		}
+		if rKind != m.Kind() || name != m.Name() {
+			if err := d.ForceNew("manifest"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if bindplane, ok := meta.(*client.BindPlane); ok {
+		if err := checkManifest(d.Id(), m, bindplane.AnyResource); err != nil {
+			return err
+		}
+	}
+
+	if err := d.SetNew("kind", string(m.Kind())); err != nil {
+		return err
+	}
+	return d.SetNew("name", m.Name())
+}
+
+// checkManifest returns an error if a component manifest references a
+// component type which does not exist, or if a manifest which Terraform
+// has not created conflicts with an existing resource. id is the
+// resource's Terraform ID, empty if it has not been created.
+func checkManifest(id string, m manifest.Manifest, lookup resourceLookup) error {
+	if typeKind, ok := manifestTypeKinds[m.Kind()]; ok {
+		spec, _ := m["spec"].(map[string]any)
+		if rType, _ := spec["type"].(string); rType != "" {
+			if err := typeExists(lookup, typeKind, rType); err != nil {
+				return fmt.Errorf("spec.type: %w", err)
+			}
+		}
+	}
+
+	if id == "" {
+		return checkResourceNotExists(lookup, m.Kind(), m.Name())
+	}
+	return nil
+}
+
+func validateManifest(v any, _ string) ([]string, []error) {
+	if _, err := manifest.Parse(v.(string)); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// suppressEquivalentManifestDiffs compares two manifests semantically,
+// ignoring formatting, key order, and metadata managed by Bindplane.
+func suppressEquivalentManifestDiffs(_, old, new string, _ *schema.ResourceData) bool {
+	oldManifest, err := manifest.Parse(old)
+	if err != nil {
+		return old == new
+	}
+
+	newManifest, err := manifest.Parse(new)
+	if err != nil {
+		return old == new
+	}
+
+	return manifest.Equal(oldManifest, newManifest)
+}
+
+// manifestID returns the Terraform ID of a manifest resource
+func manifestID(rKind model.Kind, name string) string {
+	return fmt.Sprintf("%s/%s", rKind, name)
+}
+
+// parseManifestID parses a manifest resource ID in the form kind/name
+func parseManifestID(id string) (model.Kind, string, error) {
+	rKind, name, ok := strings.Cut(id, "/")
+	if !ok || rKind == "" || name == "" {
+		return "", "", fmt.Errorf("invalid id '%s', expected kind/name, for example Role/viewer", id)
+	}
+	return model.Kind(rKind), name, nil
+}
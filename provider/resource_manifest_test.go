@@ -0,0 +1,100 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifestID(t *testing.T) {
+	cases := []struct {
+		id         string
+		expectKind model.Kind
+		expectName string
+		expectErr  bool
+	}{
+		{"Role/viewer", model.Kind("Role"), "viewer", false},
+		{"AgentVersion/v1.50.0", model.Kind("AgentVersion"), "v1.50.0", false},
+		{"viewer", "", "", true},
+		{"Role/", "", "", true},
+		{"/viewer", "", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.id, func(t *testing.T) {
+			rKind, name, err := parseManifestID(tc.id)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectKind, rKind)
+			require.Equal(t, tc.expectName, name)
+			require.Equal(t, tc.id, manifestID(rKind, name))
+		})
+	}
+}
+
+func TestSuppressEquivalentManifestDiffs(t *testing.T) {
+	yamlManifest := `
+apiVersion: bindplane.observiq.com/v1
+kind: Role
+metadata:
+  name: viewer
+spec:
+  permissions: [read]
+`
+	jsonManifest := `{"kind": "Role", "apiVersion": "bindplane.observiq.com/v1", "metadata": {"name": "viewer"}, "spec": {"permissions": ["read"]}}`
+	changed := `{"kind": "Role", "apiVersion": "bindplane.observiq.com/v1", "metadata": {"name": "viewer"}, "spec": {"permissions": ["read", "write"]}}`
+
+	require.True(t, suppressEquivalentManifestDiffs("manifest", yamlManifest, jsonManifest, nil))
+	require.False(t, suppressEquivalentManifestDiffs("manifest", yamlManifest, changed, nil))
+	require.False(t, suppressEquivalentManifestDiffs("manifest", "", jsonManifest, nil))
+}
+
+func TestCheckManifest(t *testing.T) {
+	existing := &model.AnyResource{}
+	existing.Metadata.ID = "01HZ"
+	resources := map[string]*model.AnyResource{
+		"SourceType/hostmetrics": {},
+		"Source/host":            existing,
+	}
+	lookup := func(kind model.Kind, name string) (*model.AnyResource, error) {
+		return resources[string(kind)+"/"+name], nil
+	}
+	parse := func(s string) manifest.Manifest {
+		m, err := manifest.Parse(s)
+		require.NoError(t, err)
+		return m
+	}
+
+	source := func(name, rType string) manifest.Manifest {
+		return parse(fmt.Sprintf("apiVersion: bindplane.observiq.com/v1\nkind: Source\nmetadata:\n  name: %s\nspec:\n  type: %s\n", name, rType))
+	}
+
+	require.NoError(t, checkManifest("", source("new", "hostmetrics:3"), lookup))
+	require.NoError(t, checkManifest("Source/host", source("host", "hostmetrics"), lookup))
+	require.EqualError(t, checkManifest("", source("new", "missing"), lookup), "spec.type: source type missing does not exist")
+	require.EqualError(t, checkManifest("", source("host", "hostmetrics"), lookup), "Source with name 'host' already exists with id '01HZ'")
+
+	// Manifests which are not components do not reference a type
+	role := parse("apiVersion: bindplane.observiq.com/v1\nkind: Role\nmetadata:\n  name: viewer\nspec:\n  type: missing\n")
+	require.NoError(t, checkManifest("", role, lookup))
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
@@ -29,10 +30,10 @@ import (
 
 func resourceProcessor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProcessorCreate,
-		Update: resourceProcessorCreate,
-		Read:   resourceProcessorRead,
-		Delete: resourceProcessorDelete,
+		Create:        resourceProcessorCreate,
+		Update:        resourceProcessorCreate,
+		ReadContext:   readWithDriftWarnings(model.KindProcessor, resourceProcessorRead),
+		DeleteContext: resourceProcessorDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProcessorImportState,
 		},
@@ -63,7 +64,9 @@ func resourceProcessor() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
+			"version": versionSchema,
 		},
+		CustomizeDiff: resourceCustomizeDiff("bindplane_processor"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -79,6 +82,10 @@ func resourceProcessorCreate(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindProcessor, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -125,8 +132,8 @@ func resourceProcessorRead(d *schema.ResourceData, meta any) error {
 	return genericResourceRead(model.KindProcessor, d, meta)
 }
 
-func resourceProcessorDelete(d *schema.ResourceData, meta any) error {
-	return genericResourceDelete(model.KindProcessor, d, meta)
+func resourceProcessorDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return genericResourceDelete(ctx, model.KindProcessor, d, meta)
 }
 
 func resourceProcessorImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
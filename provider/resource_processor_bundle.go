@@ -16,23 +16,31 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
 	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/observiq/terraform-provider-bindplane/internal/parameter"
 	"github.com/observiq/terraform-provider-bindplane/internal/resource"
 )
 
+// processorBundleType is the processor type of processor bundles
+const processorBundleType = "processor_bundle"
+
 func resourceProcessorBundle() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProcessorBundleCreate,
-		Update: resourceProcessorBundleCreate,
-		Read:   resourceProcessorBundleRead,
-		Delete: resourceProcessorBundleDelete,
+		Create:        resourceProcessorBundleCreate,
+		Update:        resourceProcessorBundleCreate,
+		ReadContext:   readWithDriftWarnings(model.KindProcessor, resourceProcessorBundleRead),
+		DeleteContext: resourceProcessorBundleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProcessorBundleImportState,
 		},
@@ -45,27 +53,32 @@ func resourceProcessorBundle() *schema.Resource {
 			},
 			"type": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
 				ForceNew:    false,
-				Description: "The type of the processor bundle.",
+				Description: "The type of the processor bundle. Defaults to processor_bundle.",
 			},
 			"parameters_json": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				ForceNew:    false,
-				Description: "Not implemented.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         false,
+				Description:      "A JSON object with bundle level options used to configure the processor bundle.",
+				ValidateFunc:     validateParametersJSON,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
 			},
 			"processor": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "The processors to use for the processor bundle.",
+				Description: "The processors to use for the processor bundle, referenced by name or defined inline by type. Other processor bundles can be referenced by name.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the processor.",
+							Optional:    true,
+							Description: "The name of the processor. Set either name or type.",
 						},
+						"type":            inlineTypeSchema("processor"),
+						"parameters_json": inlineParametersSchema("processor"),
 					},
 				},
 			},
@@ -75,7 +88,12 @@ func resourceProcessorBundle() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
+			"version": versionSchema,
 		},
+		CustomizeDiff: customdiff.All(
+			resourceCustomizeDiff("bindplane_processor_bundle"),
+			customizeDiffProcessorBundle,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -90,13 +108,16 @@ func resourceProcessorBundleCreate(d *schema.ResourceData, meta any) error {
 
 	processorType := d.Get("type").(string)
 	if processorType == "" {
-		// TODO(jsirianni): Move to default const
-		processorType = "processor_bundle"
+		processorType = processorBundleType
 	}
 
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindProcessor, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -116,29 +137,28 @@ func resourceProcessorBundleCreate(d *schema.ResourceData, meta any) error {
 
 	id := d.Id()
 
-	// Using resource configuration instead of []string (names)
-	// to allow for future use of type + parameters_json.
-	processors := []model.ResourceConfiguration{}
-	if d.Get("processor") != nil {
-		processorsRaw := d.Get("processor").([]any)
-		for _, v := range processorsRaw {
-			processorRaw := v.(map[string]any)
-
-			processor := model.ResourceConfiguration{}
-			if processorRaw["name"] != nil {
-				processor.Name = processorRaw["name"].(string)
-
-				// If name was set, return early to avoid
-				// setting type and parameters
-				processors = append(processors, processor)
-				continue
-			}
+	processors, err := bundleProcessors(d.Get("processor").([]any))
+	if err != nil {
+		return err
+	}
+
+	// Processor bundles referenced by name are checked for cycles at
+	// plan time, and again when applying because they may have been
+	// updated by the same apply.
+	if err := bundleCycle(name, processors, bindplane.Processor); err != nil {
+		return err
+	}
 
-			processors = append(processors, processor)
+	var parameters []model.Parameter
+	if s := d.Get("parameters_json").(string); s != "" {
+		params, err := parameter.StringToParameter(s)
+		if err != nil {
+			return err
 		}
+		parameters = params
 	}
 
-	r, err := resource.AnyResourceV1(id, name, processorType, model.KindProcessor, nil, processors)
+	r, err := resource.AnyResourceV1(id, name, processorType, model.KindProcessor, parameters, processors)
 	if err != nil {
 		return err
 	}
@@ -191,22 +211,152 @@ func resourceProcessorBundleRead(d *schema.ResourceData, meta any) error {
 		return err
 	}
 
+	if err := d.Set("version", int(g.Version)); err != nil {
+		return err
+	}
+
 	rType := strings.Split(g.Spec.Type, ":")[0]
 	if err := d.Set("type", rType); err != nil {
 		return err
 	}
 
+	// Parameters defined by the user, previously saved to state
+	stateParams := []model.Parameter{}
+	if s := d.Get("parameters_json").(string); s != "" {
+		// State is not validated, a condition which is no longer
+		// valid must not prevent the resource from being read.
+		if err := json.Unmarshal([]byte(s), &stateParams); err != nil {
+			return fmt.Errorf("state parameters: %w", err)
+		}
+	}
+
+	// Update all sensitive parameters with the values from state
+	// instead of saving "(sensitive value)" to state.
+	paramStr, err := parameter.ParametersToString(parameter.MergeSensitive(g.Spec.Parameters, stateParams))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("parameters_json", paramStr); err != nil {
+		return err
+	}
+
+	stateProcessorBlocks := d.Get("processor").([]any)
 	processorBlocks := []map[string]any{}
-	for _, p := range g.Spec.Processors {
+	for i, p := range g.Spec.Processors {
 		processor := map[string]any{}
-		processor["name"] = strings.Split(p.Name, ":")[0]
+		if err := flattenInlineComponent(p, stateBlock(stateProcessorBlocks, i), processor); err != nil {
+			return err
+		}
 		processorBlocks = append(processorBlocks, processor)
 	}
 	return d.Set("processor", processorBlocks)
 }
 
-func resourceProcessorBundleDelete(d *schema.ResourceData, meta any) error {
-	return genericResourceDelete(model.KindProcessor, d, meta)
+// bundleProcessors returns the processors of a processor bundle's processor
+// blocks. Processors are referenced by name, or defined inline with type and
+// parameters_json. Processor bundles cannot be defined inline.
+func bundleProcessors(raw []any) ([]model.ResourceConfiguration, error) {
+	processors := []model.ResourceConfiguration{}
+	for i, v := range raw {
+		processorRaw, _ := v.(map[string]any)
+
+		path := fmt.Sprintf("processor.%d", i)
+		rc, err := expandInlineComponent(processorRaw, path)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Split(rc.Type, ":")[0] == processorBundleType {
+			return nil, fmt.Errorf("%s: processor bundles cannot be defined inline, reference the bundle by name", path)
+		}
+
+		processors = append(processors, model.ResourceConfiguration{
+			Name: rc.Name,
+			ParameterizedSpec: model.ParameterizedSpec{
+				Type:       rc.Type,
+				Parameters: rc.Parameters,
+			},
+		})
+	}
+	return processors, nil
+}
+
+// customizeDiffProcessorBundle validates a processor bundle's processors
+// at plan time. The types of inline processors must exist, and processor
+// bundles referenced by name must not include the bundle being planned,
+// directly or through another nested bundle. Processors with values not
+// known until apply are not checked.
+func customizeDiffProcessorBundle(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("processor") || !d.NewValueKnown("name") {
+		return nil
+	}
+	raw, _ := d.Get("processor").([]any)
+	if hasUnknown(raw) {
+		return nil
+	}
+
+	processors, err := bundleProcessors(raw)
+	if err != nil {
+		return err
+	}
+
+	bindplane, ok := meta.(*client.BindPlane)
+	if !ok {
+		return nil
+	}
+
+	block := inlineBlock{"processor", model.KindProcessorType}
+	if errs := validateInlineBlocks(block, raw, bindplane.AnyResource); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return bundleCycle(d.Get("name").(string), processors, bindplane.Processor)
+}
+
+// bundleCycle returns an error if the processor bundle named name is reachable
+// from processors, by following processor bundles referenced by name. lookup
+// returns the named processor, or nil if it does not exist.
+func bundleCycle(name string, processors []model.ResourceConfiguration, lookup func(string) (*model.Processor, error)) error {
+	seen := map[string]struct{}{}
+
+	var walk func(path []string, processors []model.ResourceConfiguration) error
+	walk = func(path []string, processors []model.ResourceConfiguration) error {
+		for _, p := range processors {
+			if p.Name == "" {
+				continue
+			}
+
+			pName := strings.Split(p.Name, ":")[0]
+			if pName == name {
+				return fmt.Errorf("processor bundle %s cannot include itself: %s", name, strings.Join(append(path, pName), " -> "))
+			}
+
+			if _, ok := seen[pName]; ok {
+				continue
+			}
+			seen[pName] = struct{}{}
+
+			nested, err := lookup(pName)
+			if err != nil {
+				return fmt.Errorf("get processor %s: %w", pName, err)
+			}
+			// Bundles, including bundle variants with a type other
+			// than processor_bundle, are processors with processors.
+			if nested == nil || len(nested.Spec.Processors) == 0 {
+				continue
+			}
+
+			if err := walk(append(path, pName), nested.Spec.Processors); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk([]string{name}, processors)
+}
+
+func resourceProcessorBundleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return genericResourceDelete(ctx, model.KindProcessor, d, meta)
 }
 
 func resourceProcessorBundleImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
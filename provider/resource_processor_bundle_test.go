@@ -0,0 +1,126 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleCycle(t *testing.T) {
+	bundle := func(name string, processors ...string) *model.Processor {
+		p := &model.Processor{}
+		p.Metadata.Name = name
+		p.Spec.Type = processorBundleType + ":1"
+		for _, n := range processors {
+			p.Spec.Processors = append(p.Spec.Processors, model.ResourceConfiguration{Name: n})
+		}
+		return p
+	}
+
+	library := map[string]*model.Processor{
+		"batch":    {Spec: model.ParameterizedSpec{Type: "batch:2"}},
+		"inner":    bundle("inner", "batch:2"),
+		"middle":   bundle("middle", "inner:1"),
+		"circular": bundle("circular", "outer:3"),
+		"variant": {
+			Spec: model.ParameterizedSpec{
+				Type:       "custom_bundle:1",
+				Processors: []model.ResourceConfiguration{{Name: "outer:3"}},
+			},
+		},
+	}
+	lookup := func(name string) (*model.Processor, error) {
+		return library[name], nil
+	}
+
+	refs := func(names ...string) []model.ResourceConfiguration {
+		r := []model.ResourceConfiguration{
+			{ParameterizedSpec: model.ParameterizedSpec{Type: "count_telemetry"}},
+		}
+		for _, n := range names {
+			r = append(r, model.ResourceConfiguration{Name: n})
+		}
+		return r
+	}
+
+	cases := []struct {
+		name       string
+		processors []model.ResourceConfiguration
+		expectErr  string
+	}{
+		{
+			"processors",
+			refs("batch", "missing"),
+			"",
+		},
+		{
+			"nested-bundles",
+			refs("middle", "inner"),
+			"",
+		},
+		{
+			"self",
+			refs("outer"),
+			"processor bundle outer cannot include itself: outer -> outer",
+		},
+		{
+			"nested-cycle",
+			refs("batch", "circular"),
+			"processor bundle outer cannot include itself: outer -> circular -> outer",
+		},
+		{
+			"bundle-variant",
+			refs("variant"),
+			"processor bundle outer cannot include itself: outer -> variant -> outer",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := bundleCycle("outer", tc.processors, lookup)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBundleProcessors(t *testing.T) {
+	processors, err := bundleProcessors([]any{
+		map[string]any{"name": "library"},
+		map[string]any{"type": "batch", "parameters_json": `[{"name": "timeout", "value": "2s"}]`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []model.ResourceConfiguration{
+		{Name: "library"},
+		{
+			ParameterizedSpec: model.ParameterizedSpec{
+				Type:       "batch",
+				Parameters: []model.Parameter{{Name: "timeout", Value: "2s"}},
+			},
+		},
+	}, processors)
+
+	_, err = bundleProcessors([]any{map[string]any{"name": "a"}, map[string]any{"type": processorBundleType}})
+	require.EqualError(t, err, "processor.1: processor bundles cannot be defined inline, reference the bundle by name")
+
+	_, err = bundleProcessors([]any{map[string]any{"name": "a", "type": "batch"}})
+	require.ErrorContains(t, err, "processor.0: name and type cannot both be set")
+}
@@ -0,0 +1,273 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/configuration"
+	"github.com/observiq/terraform-provider-bindplane/internal/maputil"
+	"github.com/observiq/terraform-provider-bindplane/internal/resource"
+)
+
+func resourceRawConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceRawConfigurationCreate,
+		Update:        resourceRawConfigurationCreate, // Run create as update
+		ReadContext:   readWithDriftWarnings(model.KindConfiguration, resourceRawConfigurationRead),
+		DeleteContext: genericConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRawConfigurationImportState,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the raw configuration.",
+			},
+			"platform": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					platform := val.(string)
+					if !isValidPlatform(platform) {
+						errs = append(errs, fmt.Errorf("%s is not a valid platform", platform))
+					}
+					return
+				},
+				Description: "The platform the raw configuration is for.",
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: false,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					labels := val.(map[string]any)
+					_, ok := labels["platform"]
+					if ok {
+						errs = append(errs, errors.New("label 'platform' will be overwritten by the configured platform"))
+					}
+					return
+				},
+				Description: "Key value pairs which will be added to the raw configuration as labels.",
+			},
+			"match_labels": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				ForceNew:    false,
+				Description: "Labels that Bindplane uses to determine which agents the raw configuration should apply to. This value is computed from the selector block.",
+			},
+			"selector": selectorSchema,
+			"otel_yaml": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: false,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					if err := configuration.ValidateRaw(val.(string)); err != nil {
+						errs = append(errs, err)
+					}
+					return
+				},
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return configuration.EquivalentRaw(old, new)
+				},
+				Description: "The OpenTelemetry collector configuration YAML.",
+			},
+			"rollout": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				ForceNew:    false,
+				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
+			},
+			"version":         versionSchema,
+			"current_version": currentVersionSchema,
+			"pending_version": pendingVersionSchema,
+		},
+		CustomizeDiff: configurationCustomizeDiff("bindplane_raw_configuration"),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(maxTimeout),
+			Read:   schema.DefaultTimeout(maxTimeout),
+			Delete: schema.DefaultTimeout(maxTimeout),
+		},
+	}
+}
+
+func resourceRawConfigurationCreate(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	name := d.Get("name").(string)
+	rollout := d.Get("rollout").(bool)
+
+	if err := checkResourceVersion(bindplane, model.KindConfiguration, name, d); err != nil {
+		return err
+	}
+
+	// If id is unset, it means Terraform has not previously created
+	// this resource. Check to ensure a resource with this name does
+	// not already exist.
+	if d.Id() == "" {
+		c, err := bindplane.Configuration(name)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			return fmt.Errorf("configuration with name '%s' already exists with id '%s'", name, c.ID())
+		}
+	}
+
+	labels, err := maputil.StringMapFromTFMap(d.Get("labels").(map[string]any))
+	if err != nil {
+		return err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["platform"] = d.Get("platform").(string)
+
+	matchLabels, err := configurationMatchLabels(d.Get, name)
+	if err != nil {
+		return err
+	}
+
+	opts := []configuration.Option{
+		configuration.WithName(name),
+		configuration.WithLabels(labels),
+		configuration.WithMatchLabels(matchLabels),
+		configuration.WithRaw(d.Get("otel_yaml").(string)),
+	}
+
+	config, err := configuration.NewV1(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create new raw configuration: %w", err)
+	}
+
+	resource := resource.AnyResourceFromConfigurationV1(config)
+	ctx := context.Background()
+	timeout := d.Timeout(schema.TimeoutCreate) - time.Minute
+	if err := bindplane.ApplyWithRetry(ctx, timeout, &resource, rollout); err != nil {
+		return err
+	}
+
+	return resourceRawConfigurationRead(d, meta)
+}
+
+func resourceRawConfigurationRead(d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	config, err := bindplane.Configuration(d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	if config == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// If the state ID is set but differs from the ID returned by
+	// bindplane, mark the resource to be re-created by unsetting
+	// the ID, see resourceConfigurationRead.
+	if id := d.Id(); id != "" && config.ID() != id {
+		d.SetId("")
+		return nil
+	}
+	d.SetId(config.ID())
+
+	if err := d.Set("name", config.Name()); err != nil {
+		return err
+	}
+
+	if err := d.Set("version", int(config.Version())); err != nil {
+		return err
+	}
+
+	if err := setConfigurationVersions(d, config); err != nil {
+		return err
+	}
+
+	labels := config.Metadata.Labels.AsMap()
+	if platform, ok := labels["platform"]; ok {
+		if err := d.Set("platform", platform); err != nil {
+			return err
+		}
+		// Remove the platform label from the labels map
+		// because Terraform's state does not expect it.
+		delete(labels, "platform")
+	}
+
+	if err := d.Set("labels", labels); err != nil {
+		return err
+	}
+
+	matchLabels := make(map[string]string)
+	for k, v := range config.Spec.Selector.MatchLabels {
+		matchLabels[k] = v
+	}
+	if err := d.Set("match_labels", matchLabels); err != nil {
+		return err
+	}
+
+	if err := setSelector(d, config.Name(), matchLabels); err != nil {
+		return err
+	}
+
+	// Keep the YAML in state as written unless it changed, so
+	// that formatting differences do not show in plans.
+	if !configuration.EquivalentRaw(d.Get("otel_yaml").(string), config.Spec.Raw) {
+		if err := d.Set("otel_yaml", config.Spec.Raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceRawConfigurationImportState imports a raw configuration
+// by its name.
+func resourceRawConfigurationImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	bindplane := meta.(*client.BindPlane)
+
+	// When importing, name is not set in the state so we need to grab
+	// the ID instead, which is the same as "name".
+	name := d.Id()
+
+	config, err := bindplane.Configuration(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("configuration with name '%s' does not exist", name)
+	}
+
+	if config.Spec.Raw == "" {
+		return nil, fmt.Errorf("configuration '%s' is not a raw configuration, import it with bindplane_configuration or bindplane_configuration_v2", name)
+	}
+
+	d.SetId(config.ID())
+	if err := d.Set("name", config.Name()); err != nil {
+		return nil, fmt.Errorf("failed to set resource name in state for imported configuration '%s': %v", name, err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
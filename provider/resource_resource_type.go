@@ -0,0 +1,346 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/manifest"
+	"github.com/observiq/terraform-provider-bindplane/internal/resourcetype"
+)
+
+func resourceSourceType() *schema.Resource {
+	return resourceResourceType(model.KindSourceType, "bindplane_source_type")
+}
+
+func resourceDestinationType() *schema.Resource {
+	return resourceResourceType(model.KindDestinationType, "bindplane_destination_type")
+}
+
+func resourceProcessorType() *schema.Resource {
+	return resourceResourceType(model.KindProcessorType, "bindplane_processor_type")
+}
+
+func resourceExtensionType() *schema.Resource {
+	return resourceResourceType(model.KindExtensionType, "bindplane_extension_type")
+}
+
+func resourceConnectorType() *schema.Resource {
+	return resourceResourceType(model.KindConnectorType, "bindplane_connector_type")
+}
+
+// resourceResourceType returns a resource which manages custom
+// resource types of the given kind.
+func resourceResourceType(rKind model.Kind, rType string) *schema.Resource {
+	// An empty template block renders nothing, and is not saved by
+	// Bindplane, so each block must set at least one template.
+	templateSchema := func(telemetryType string) *schema.Schema {
+		keys := []string{"receivers", "processors", "exporters", "extensions", "connectors"}
+		atLeastOneOf := []string{}
+		for _, k := range keys {
+			atLeastOneOf = append(atLeastOneOf, fmt.Sprintf("%s.0.%s", telemetryType, k))
+		}
+		templates := map[string]*schema.Schema{}
+		for _, k := range keys {
+			templates[k] = &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: atLeastOneOf,
+				Description:  fmt.Sprintf("Go template which renders a YAML list of %s.", k),
+			}
+		}
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "The OpenTelemetry configuration templates rendered for this telemetry type. At least one template must be set.",
+			Elem:        &schema.Resource{Schema: templates},
+		}
+	}
+
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta any) error {
+			return resourceResourceTypeCreate(rKind, d, meta)
+		},
+		Update: func(d *schema.ResourceData, meta any) error {
+			return resourceResourceTypeCreate(rKind, d, meta)
+		},
+		ReadContext: readWithDriftWarnings(rKind, func(d *schema.ResourceData, meta any) error {
+			return resourceResourceTypeRead(rKind, d, meta)
+		}),
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			return genericResourceDelete(ctx, rKind, d, meta)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+				return genericResourceImport(rKind, d, meta)
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the resource type. Components use the type by setting their type to this name.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The resource type's display name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The resource type's description.",
+			},
+			"parameters_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "A JSON list of parameter definitions, each with at least a name and type.",
+				ValidateFunc:     validateParameterDefinitions,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+			"supported_platforms": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The agent platforms the resource type supports.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(resourcetype.Platforms, false),
+				},
+			},
+			"telemetry_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The telemetry types the resource type supports.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(resourcetype.TelemetryTypes, false),
+				},
+			},
+			"logs":    templateSchema("logs"),
+			"metrics": templateSchema("metrics"),
+			"traces":  templateSchema("traces"),
+			"version": versionSchema,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceCustomizeDiff(rType),
+			customizeDiffResourceType(rKind),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(maxTimeout),
+			Read:   schema.DefaultTimeout(maxTimeout),
+			Delete: schema.DefaultTimeout(maxTimeout),
+		},
+	}
+}
+
+func resourceResourceTypeCreate(rKind model.Kind, d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	name := d.Get("name").(string)
+
+	if err := checkResourceVersion(bindplane, rKind, name, d); err != nil {
+		return err
+	}
+
+	// If id is unset, it means Terraform has not previously created
+	// this resource. Check to ensure a resource with this name does
+	// not already exist.
+	if d.Id() == "" {
+		g, err := bindplane.GenericResource(rKind, name)
+		if err != nil {
+			return err
+		}
+		if g != nil {
+			return fmt.Errorf("%s with name '%s' already exists with id '%s'", rKind, name, g.ID)
+		}
+	}
+
+	parameters, err := resourcetype.ParseParameters(d.Get("parameters_json").(string))
+	if err != nil {
+		return err
+	}
+
+	rt := &resourcetype.ResourceType{
+		Kind:               rKind,
+		Name:               name,
+		DisplayName:        d.Get("display_name").(string),
+		Description:        d.Get("description").(string),
+		Parameters:         parameters,
+		SupportedPlatforms: stringList(d.Get("supported_platforms").([]any)),
+		TelemetryTypes:     stringList(d.Get("telemetry_types").([]any)),
+		Templates:          map[string]resourcetype.Template{},
+	}
+
+	for _, telemetryType := range resourcetype.TelemetryTypes {
+		blocks := d.Get(telemetryType).([]any)
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+		block := blocks[0].(map[string]any)
+		rt.Templates[telemetryType] = resourcetype.Template{
+			Receivers:  block["receivers"].(string),
+			Processors: block["processors"].(string),
+			Exporters:  block["exporters"].(string),
+			Extensions: block["extensions"].(string),
+			Connectors: block["connectors"].(string),
+		}
+	}
+
+	r, err := rt.AnyResource()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	timeout := d.Timeout(schema.TimeoutCreate) - time.Minute
+	if err := bindplane.ApplyWithRetry(ctx, timeout, &r, false); err != nil {
+		return err
+	}
+
+	return resourceResourceTypeRead(rKind, d, meta)
+}
+
+func resourceResourceTypeRead(rKind model.Kind, d *schema.ResourceData, meta any) error {
+	bindplane := meta.(*client.BindPlane)
+
+	a, err := bindplane.AnyResource(rKind, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	// A nil return from AnyResource indicates that the resource
+	// did not exist. Terraform read operations should always set the
+	// ID to "" and return a nil error. This will allow Terraform to
+	// re-create the resource or confirm that it was deleted.
+	if a == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// If the state ID is set but differs from the ID returned by
+	// bindplane, the resource was re-created by other means. Mark
+	// the resource to be re-created, see genericResourceRead.
+	if d.Id() != "" && a.ID() != d.Id() {
+		d.SetId("")
+		return nil
+	}
+	d.SetId(a.ID())
+
+	rt, err := resourcetype.FromAnyResource(a)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("name", rt.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("display_name", rt.DisplayName); err != nil {
+		return err
+	}
+
+	if err := d.Set("description", rt.Description); err != nil {
+		return err
+	}
+
+	// Bindplane adds defaults to parameter definitions. Keep the
+	// definitions in state as written if Bindplane's copy contains them.
+	current, err := resourcetype.ParseParameters(d.Get("parameters_json").(string))
+	if err != nil || !manifest.ContainsValue(jsonValue(rt.Parameters), jsonValue(current)) {
+		parameters, err := resourcetype.ParametersToString(rt.Parameters)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("parameters_json", parameters); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("supported_platforms", rt.SupportedPlatforms); err != nil {
+		return err
+	}
+
+	if err := d.Set("telemetry_types", rt.TelemetryTypes); err != nil {
+		return err
+	}
+
+	for _, telemetryType := range resourcetype.TelemetryTypes {
+		blocks := []any{}
+		if t, ok := rt.Templates[telemetryType]; ok {
+			blocks = append(blocks, map[string]any{
+				"receivers":  t.Receivers,
+				"processors": t.Processors,
+				"exporters":  t.Exporters,
+				"extensions": t.Extensions,
+				"connectors": t.Connectors,
+			})
+		}
+		if err := d.Set(telemetryType, blocks); err != nil {
+			return err
+		}
+	}
+
+	return d.Set("version", int(a.Version()))
+}
+
+// customizeDiffResourceType returns a plan time error when a resource
+// type which Terraform will create already exists in Bindplane.
+func customizeDiffResourceType(rKind model.Kind) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta any) error {
+		bindplane, ok := meta.(*client.BindPlane)
+		if !ok || d.Id() != "" || !d.NewValueKnown("name") {
+			return nil
+		}
+		return checkResourceNotExists(bindplane.AnyResource, rKind, d.Get("name").(string))
+	}
+}
+
+func validateParameterDefinitions(v any, _ string) ([]string, []error) {
+	if _, err := resourcetype.ParseParameters(v.(string)); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// stringList converts a Terraform list of strings
+func stringList(l []any) []string {
+	s := make([]string, 0, len(l))
+	for _, v := range l {
+		if v, ok := v.(string); ok {
+			s = append(s, v)
+		}
+	}
+	return s
+}
+
+// jsonValue converts parameter definitions to a []any
+// for comparison with manifest.ContainsValue.
+func jsonValue(parameters []map[string]any) []any {
+	l := make([]any, 0, len(parameters))
+	for _, p := range parameters {
+		l = append(l, p)
+	}
+	return l
+}
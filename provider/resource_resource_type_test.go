@@ -0,0 +1,51 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceResourceTypeTemplates(t *testing.T) {
+	r := resourceResourceType(model.KindSourceType, "bindplane_source_type")
+
+	validate := func(raw map[string]any) diag.Diagnostics {
+		return r.Validate(terraform.NewResourceConfigRaw(raw))
+	}
+
+	require.Empty(t, validate(map[string]any{
+		"name": "my-source",
+		"logs": []any{map[string]any{"receivers": "- filelog: {}"}},
+	}))
+	require.Empty(t, validate(map[string]any{
+		"name":    "my-source",
+		"metrics": []any{map[string]any{"processors": "- batch: {}", "exporters": "- otlp: {}"}},
+	}))
+
+	// An empty block would not be saved by Bindplane, and would be
+	// planned again after every apply.
+	diags := validate(map[string]any{
+		"name":   "my-source",
+		"logs":   []any{map[string]any{"receivers": "- filelog: {}"}},
+		"traces": []any{map[string]any{}},
+	})
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Detail, "traces.0.receivers")
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/observiq/bindplane-op-enterprise/model"
 	"github.com/observiq/terraform-provider-bindplane/client"
@@ -30,10 +31,10 @@ import (
 // TODO(jsirianni): Decide if sources should be supported. Currently not implemented by the provider.
 func resourceSource() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSourceCreate,
-		Update: resourceSourceCreate,
-		Read:   resourceSourceRead,
-		Delete: resourceSourceDelete,
+		Create:        resourceSourceCreate,
+		Update:        resourceSourceCreate,
+		ReadContext:   readWithDriftWarnings(model.KindSource, resourceSourceRead),
+		DeleteContext: resourceSourceDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSourceImportState,
 		},
@@ -63,7 +64,9 @@ func resourceSource() *schema.Resource {
 				ForceNew:    false,
 				Description: "Whether or not to trigger a rollout automatically when a configuration is updated. When set to true, Bindplane will automatically roll out the configuration change to managed agents.",
 			},
+			"version": versionSchema,
 		},
+		CustomizeDiff: resourceCustomizeDiff("bindplane_source"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(maxTimeout),
 			Read:   schema.DefaultTimeout(maxTimeout),
@@ -79,6 +82,10 @@ func resourceSourceCreate(d *schema.ResourceData, meta any) error {
 	name := d.Get("name").(string)
 	rollout := d.Get("rollout").(bool)
 
+	if err := checkResourceVersion(bindplane, model.KindSource, name, d); err != nil {
+		return err
+	}
+
 	// If id is unset, it means Terraform has not previously created
 	// this resource. Check to ensure a resource with this name does
 	// not already exist.
@@ -125,8 +132,8 @@ func resourceSourceRead(d *schema.ResourceData, meta any) error {
 	return genericResourceRead(model.KindSource, d, meta)
 }
 
-func resourceSourceDelete(d *schema.ResourceData, meta any) error {
-	return genericResourceDelete(model.KindSource, d, meta)
+func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	return genericResourceDelete(ctx, model.KindSource, d, meta)
 }
 
 func resourceSourceImportState(_ context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
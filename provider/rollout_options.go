@@ -0,0 +1,531 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/maputil"
+)
+
+const (
+	rolloutTypeStandard    = "standard"
+	rolloutTypeProgressive = "progressive"
+
+	// Rollout parameter names used by Bindplane
+	rolloutParamStages     = "stages"
+	rolloutParamMaxErrors  = "maxErrors"
+	rolloutParamInitial    = "initial"
+	rolloutParamMultiplier = "multiplier"
+	rolloutParamMaxAgents  = "maxAgents"
+
+	// Stage options used by Bindplane
+	stageName            = "name"
+	stageLabels          = "labels"
+	stagePauseDuration   = "pauseDuration"
+	stageRequireApproval = "requireApproval"
+)
+
+// rolloutOptionsSchema is the rollout_options block of configurations
+var rolloutOptionsSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	ForceNew: false,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					t := val.(string)
+					if t != rolloutTypeStandard && t != rolloutTypeProgressive {
+						errs = append(errs, fmt.Errorf("invalid rollout type: %s", t))
+					}
+					return
+				},
+				ForceNew:    false,
+				Description: "The type of rollout to perform. Valid values are 'standard' and 'progressive'.",
+			},
+			"max_errors": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAtLeast(0),
+				Description:  "The number of agents that can fail to apply the configuration before the rollout is paused. Set to 0 to pause on the first error. Defaults to Bindplane's default when unset.",
+			},
+			"initial_agent_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAtLeast(1),
+				Description:  "The number of agents updated in the first phase of the rollout. Defaults to Bindplane's default when unset.",
+			},
+			"multiplier": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+					if m := val.(float64); m < 1 {
+						errs = append(errs, fmt.Errorf("multiplier must be at least 1, got %v", m))
+					}
+					return
+				},
+				Description: "The factor the number of agents is multiplied by after each phase of the rollout. Defaults to Bindplane's default when unset.",
+			},
+			"max_agent_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAtLeast(1),
+				Description:  "The maximum number of agents updated in a single phase of the rollout. Defaults to Bindplane's default when unset.",
+			},
+			"stage": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the stage.",
+						},
+						"labels": {
+							Type:         schema.TypeMap,
+							Required:     true,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateStageLabels,
+							Description:  "Labels selecting the agents in the stage.",
+						},
+						"pause_duration": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: func(val any, _ string) (warns []string, errs []error) {
+								if _, err := time.ParseDuration(val.(string)); err != nil {
+									errs = append(errs, fmt.Errorf("invalid pause_duration: %w", err))
+								}
+								return
+							},
+							Description: "How long to pause after the stage completes before starting the next stage, such as '30m'.",
+						},
+						"require_approval": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether the stage must be approved before the rollout continues to the next stage.",
+						},
+					},
+				},
+				Description: "Stages of a progressive rollout, performed in order. Cannot be used with parameters.",
+			},
+			"parameters": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the parameter.",
+						},
+						"value": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"labels": {
+										Type:         schema.TypeMap,
+										Required:     true,
+										ValidateFunc: validateStageLabels,
+										Description:  "Labels for the parameter.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the stage.",
+									},
+								},
+							},
+							Description: "Value of the parameter, which is a list of stages.",
+						},
+					},
+				},
+				Description: "List of parameters for the rollout options. Prefer the stage block.",
+			},
+		},
+	},
+	Description: "Options for configuring the rollout behavior of the configuration.",
+}
+
+// validateAtLeast returns a ValidateFunc which ensures an int is at least min
+func validateAtLeast(min int) schema.SchemaValidateFunc {
+	return func(val any, key string) (warns []string, errs []error) {
+		if v := val.(int); v < min {
+			errs = append(errs, fmt.Errorf("%s must be at least %d, got %d", key, min, v))
+		}
+		return
+	}
+}
+
+// validateStageLabels ensures the labels of a rollout stage are valid
+// Bindplane labels.
+func validateStageLabels(val any, _ string) (warns []string, errs []error) {
+	m, err := maputil.StringMapFromTFMap(val.(map[string]any))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	if len(m) == 0 {
+		return nil, []error{errors.New("stage labels must contain at least one label")}
+	}
+
+	if _, err := model.LabelsFromMap(m); err != nil {
+		errs = append(errs, fmt.Errorf("invalid stage labels: %w", err))
+	}
+	return
+}
+
+// readRolloutOptions safely reads "rollout_options" from the resource data.
+func readRolloutOptions(d *schema.ResourceData) (model.ResourceConfiguration, error) {
+	rolloutOptionsRaw, ok := d.GetOk("rollout_options")
+	if !ok || len(rolloutOptionsRaw.([]interface{})) == 0 {
+		return model.ResourceConfiguration{}, nil
+	}
+
+	// Because d.GetOk returned a non nil value, we can assume that the
+	// rollout_options list has at least one element due to the Terraform
+	// framework's schema validation. Type assertion is safe in this case.
+
+	rolloutOptions := rolloutOptionsRaw.([]interface{})[0].(map[string]interface{})
+	resourceConfig := model.ResourceConfiguration{}
+
+	if t, ok := rolloutOptions["type"].(string); ok {
+		resourceConfig.Type = t
+	}
+
+	parameters, err := expandRolloutParameters(resourceConfig.Type, rolloutOptions, configuredRolloutOptions(d))
+	if err != nil {
+		return model.ResourceConfiguration{}, err
+	}
+
+	if parametersRaw, ok := rolloutOptions["parameters"]; ok && len(parametersRaw.([]interface{})) > 0 {
+		if len(parameters) > 0 {
+			return model.ResourceConfiguration{}, errors.New("parameters cannot be used with stage, max_errors, initial_agent_count, multiplier, or max_agent_count")
+		}
+
+		parametersList := parametersRaw.([]interface{})
+		parameters = make([]model.Parameter, len(parametersList))
+		for i, p := range parametersList {
+			paramMap := p.(map[string]interface{})
+			param := model.Parameter{}
+			if name, ok := paramMap["name"].(string); ok {
+				param.Name = name
+			}
+			if valueRaw, ok := paramMap["value"]; ok {
+				valueList := valueRaw.([]interface{})
+				values := make([]interface{}, len(valueList))
+				for j, v := range valueList {
+					values[j] = v.(map[string]interface{})
+				}
+				param.Value = values
+			}
+			parameters[i] = param
+		}
+	}
+
+	if len(parameters) > 0 {
+		resourceConfig.Parameters = parameters
+	}
+
+	return resourceConfig, nil
+}
+
+// rolloutDefaultedOptions are the rollout_options attributes which
+// Bindplane defaults when they are not set.
+var rolloutDefaultedOptions = []string{"max_errors", "initial_agent_count", "multiplier", "max_agent_count"}
+
+// configuredRolloutOptions returns the rollout_options attributes set in the
+// configuration. The attributes are computed, so their values include defaults
+// read from Bindplane, and 0 is a valid max_errors. When the raw configuration
+// is not available, attributes with non-zero values are considered set.
+func configuredRolloutOptions(d *schema.ResourceData) map[string]bool {
+	if configured, ok := rawConfiguredRolloutOptions(d.GetRawConfig()); ok {
+		return configured
+	}
+
+	configured := map[string]bool{}
+	for _, key := range rolloutDefaultedOptions {
+		if _, ok := d.GetOk("rollout_options.0." + key); ok {
+			configured[key] = true
+		}
+	}
+	return configured
+}
+
+// rawConfiguredRolloutOptions returns the rollout_options attributes which
+// are not null in a resource's raw configuration. Returns false if the raw
+// configuration is not available.
+func rawConfiguredRolloutOptions(raw cty.Value) (map[string]bool, bool) {
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil, false
+	}
+
+	configured := map[string]bool{}
+	options := raw.GetAttr("rollout_options")
+	if options.IsNull() || !options.IsKnown() || options.LengthInt() == 0 {
+		return configured, true
+	}
+
+	block := options.Index(cty.NumberIntVal(0))
+	for _, key := range rolloutDefaultedOptions {
+		if !block.GetAttr(key).IsNull() {
+			configured[key] = true
+		}
+	}
+	return configured, true
+}
+
+// expandRolloutParameters returns the Bindplane rollout parameters for the
+// stage, max_errors, initial_agent_count, multiplier, and max_agent_count
+// options. configured are the options set in the configuration, options
+// which are not set are omitted so that Bindplane uses its defaults.
+func expandRolloutParameters(rolloutType string, rolloutOptions map[string]any, configured map[string]bool) ([]model.Parameter, error) {
+	parameters := []model.Parameter{}
+
+	if stagesRaw, _ := rolloutOptions["stage"].([]any); len(stagesRaw) > 0 {
+		if rolloutType != rolloutTypeProgressive {
+			return nil, fmt.Errorf("stage can only be used with %s rollouts", rolloutTypeProgressive)
+		}
+
+		stages := make([]any, 0, len(stagesRaw))
+		for _, s := range stagesRaw {
+			stageRaw, _ := s.(map[string]any)
+
+			stage := map[string]any{
+				stageName:   stageRaw["name"],
+				stageLabels: stageRaw["labels"],
+			}
+			if p, _ := stageRaw["pause_duration"].(string); p != "" {
+				stage[stagePauseDuration] = p
+			}
+			if a, _ := stageRaw["require_approval"].(bool); a {
+				stage[stageRequireApproval] = true
+			}
+			stages = append(stages, stage)
+		}
+		parameters = append(parameters, model.Parameter{Name: rolloutParamStages, Value: stages})
+	}
+
+	if configured["max_errors"] {
+		v, _ := rolloutOptions["max_errors"].(int)
+		parameters = append(parameters, model.Parameter{Name: rolloutParamMaxErrors, Value: v})
+	}
+
+	initial, _ := rolloutOptions["initial_agent_count"].(int)
+	if configured["initial_agent_count"] {
+		parameters = append(parameters, model.Parameter{Name: rolloutParamInitial, Value: initial})
+	}
+
+	if configured["multiplier"] {
+		v, _ := rolloutOptions["multiplier"].(float64)
+		parameters = append(parameters, model.Parameter{Name: rolloutParamMultiplier, Value: v})
+	}
+
+	maxAgents, _ := rolloutOptions["max_agent_count"].(int)
+	if configured["max_agent_count"] {
+		if configured["initial_agent_count"] && initial > maxAgents {
+			return nil, fmt.Errorf("initial_agent_count (%d) cannot be greater than max_agent_count (%d)", initial, maxAgents)
+		}
+		parameters = append(parameters, model.Parameter{Name: rolloutParamMaxAgents, Value: maxAgents})
+	}
+
+	return parameters, nil
+}
+
+// resourceConfigurationRolloutOptionsRead takes a configuration's rollout options
+// and sets them in the Terraform state. This will trigger a terraform apply if the
+// rollout options have changed outside of Terraform.
+func resourceConfigurationRolloutOptionsRead(d *schema.ResourceData, rollout model.ResourceConfiguration) error {
+	stateOptions := []any{}
+	if v, ok := d.Get("rollout_options").([]any); ok {
+		stateOptions = v
+	}
+
+	// Bindplane uses a standard rollout without parameters when rollout
+	// options are not configured.
+	if rollout.Type == "" || (len(stateOptions) == 0 && rollout.Type == rolloutTypeStandard && len(rollout.Parameters) == 0) {
+		if err := d.Set("rollout_options", []any{}); err != nil {
+			return fmt.Errorf("error setting rollout options: %s", err)
+		}
+		return nil
+	}
+
+	// Stages are written to the parameters option when state uses it,
+	// otherwise to the stage option.
+	legacy := false
+	if state := stateBlock(stateOptions, 0); state != nil {
+		params, _ := state["parameters"].([]any)
+		legacy = len(params) > 0
+	}
+
+	rolloutOptions, err := flattenRolloutOptions(rollout, legacy)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("rollout_options", []interface{}{rolloutOptions}); err != nil {
+		return fmt.Errorf("error setting rollout options: %s", err)
+	}
+
+	return nil
+}
+
+// flattenRolloutOptions converts rollout options returned by Bindplane to a
+// rollout_options block. When legacy is true, stages are written to the
+// parameters option instead of the stage option.
+func flattenRolloutOptions(rollout model.ResourceConfiguration, legacy bool) (map[string]any, error) {
+	rolloutOptions := map[string]any{
+		"type":                rollout.Type,
+		"max_errors":          0,
+		"initial_agent_count": 0,
+		"multiplier":          float64(0),
+		"max_agent_count":     0,
+		"stage":               []any{},
+		"parameters":          []any{},
+	}
+
+	parameters := []any{}
+	for _, param := range rollout.Parameters {
+		switch param.Name {
+		case rolloutParamMaxErrors:
+			rolloutOptions["max_errors"] = intValue(param.Value)
+		case rolloutParamInitial:
+			rolloutOptions["initial_agent_count"] = intValue(param.Value)
+		case rolloutParamMultiplier:
+			rolloutOptions["multiplier"] = floatValue(param.Value)
+		case rolloutParamMaxAgents:
+			rolloutOptions["max_agent_count"] = intValue(param.Value)
+		case rolloutParamStages:
+			stages, err := flattenStages(param.Value)
+			if err != nil {
+				return nil, err
+			}
+			if !legacy {
+				rolloutOptions["stage"] = stages
+				continue
+			}
+			parameters = append(parameters, map[string]any{
+				"name":  param.Name,
+				"value": legacyStages(stages),
+			})
+		default:
+			// Unknown parameters can only be represented by the
+			// parameters option when their value is a list of stages.
+			stages, err := flattenStages(param.Value)
+			if err != nil {
+				continue
+			}
+			parameters = append(parameters, map[string]any{
+				"name":  param.Name,
+				"value": legacyStages(stages),
+			})
+		}
+	}
+	rolloutOptions["parameters"] = parameters
+
+	return rolloutOptions, nil
+}
+
+// flattenStages converts the stages parameter of a rollout to stage blocks
+func flattenStages(value any) ([]any, error) {
+	stagesRaw, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected rollout stages type %T", value)
+	}
+
+	stages := make([]any, 0, len(stagesRaw))
+	for _, s := range stagesRaw {
+		stageRaw, ok := s.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected rollout stage type %T", s)
+		}
+
+		labels := map[string]any{}
+		if l, ok := stageRaw[stageLabels].(map[string]any); ok {
+			labels = l
+		}
+
+		stage := map[string]any{
+			"name":             stageRaw[stageName],
+			"labels":           labels,
+			"pause_duration":   "",
+			"require_approval": false,
+		}
+		if p, ok := stageRaw[stagePauseDuration].(string); ok {
+			stage["pause_duration"] = p
+		}
+		if a, ok := stageRaw[stageRequireApproval].(bool); ok {
+			stage["require_approval"] = a
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// legacyStages returns stage blocks with only the name and labels
+// options supported by the parameters option.
+func legacyStages(stages []any) []any {
+	legacy := make([]any, 0, len(stages))
+	for _, s := range stages {
+		stage := s.(map[string]any)
+		legacy = append(legacy, map[string]any{
+			"name":   stage["name"],
+			"labels": stage["labels"],
+		})
+	}
+	return legacy
+}
+
+// intValue converts a numeric parameter value to an int
+func intValue(v any) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// floatValue converts a numeric parameter value to a float64
+func floatValue(v any) float64 {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/stretchr/testify/require"
+)
+
+func rolloutOptionsResourceData(t *testing.T, rolloutOptions map[string]any) *schema.ResourceData {
+	raw := map[string]any{}
+	if rolloutOptions != nil {
+		raw["rollout_options"] = []any{rolloutOptions}
+	}
+	return schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"rollout_options": rolloutOptionsSchema,
+	}, raw)
+}
+
+func TestReadRolloutOptionsStages(t *testing.T) {
+	d := rolloutOptionsResourceData(t, map[string]any{
+		"type":                "progressive",
+		"max_errors":          2,
+		"initial_agent_count": 5,
+		"multiplier":          1.5,
+		"max_agent_count":     50,
+		"stage": []any{
+			map[string]any{
+				"name":   "stage",
+				"labels": map[string]any{"env": "stage"},
+			},
+			map[string]any{
+				"name":             "production",
+				"labels":           map[string]any{"env": "production"},
+				"pause_duration":   "30m",
+				"require_approval": true,
+			},
+		},
+	})
+
+	rollout, err := readRolloutOptions(d)
+	require.NoError(t, err)
+	require.Equal(t, model.ResourceConfiguration{
+		ParameterizedSpec: model.ParameterizedSpec{
+			Type: "progressive",
+			Parameters: []model.Parameter{
+				{
+					Name: "stages",
+					Value: []any{
+						map[string]any{
+							"name":   "stage",
+							"labels": map[string]any{"env": "stage"},
+						},
+						map[string]any{
+							"name":            "production",
+							"labels":          map[string]any{"env": "production"},
+							"pauseDuration":   "30m",
+							"requireApproval": true,
+						},
+					},
+				},
+				{Name: "maxErrors", Value: 2},
+				{Name: "initial", Value: 5},
+				{Name: "multiplier", Value: 1.5},
+				{Name: "maxAgents", Value: 50},
+			},
+		},
+	}, rollout)
+}
+
+func TestReadRolloutOptionsErrors(t *testing.T) {
+	stage := []any{
+		map[string]any{
+			"name":   "stage",
+			"labels": map[string]any{"env": "stage"},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		options   map[string]any
+		expectErr string
+	}{
+		{
+			"standard-with-stages",
+			map[string]any{
+				"type":  "standard",
+				"stage": stage,
+			},
+			"stage can only be used with progressive rollouts",
+		},
+		{
+			"parameters-and-stages",
+			map[string]any{
+				"type":  "progressive",
+				"stage": stage,
+				"parameters": []any{
+					map[string]any{
+						"name":  "stages",
+						"value": stage,
+					},
+				},
+			},
+			"parameters cannot be used with stage",
+		},
+		{
+			"initial-greater-than-max",
+			map[string]any{
+				"type":                "standard",
+				"initial_agent_count": 10,
+				"max_agent_count":     5,
+			},
+			"initial_agent_count (10) cannot be greater than max_agent_count (5)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readRolloutOptions(rolloutOptionsResourceData(t, tc.options))
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestRawConfiguredRolloutOptions(t *testing.T) {
+	options := func(attrs map[string]cty.Value) cty.Value {
+		block := map[string]cty.Value{
+			"type":                cty.StringVal("progressive"),
+			"max_errors":          cty.NullVal(cty.Number),
+			"initial_agent_count": cty.NullVal(cty.Number),
+			"multiplier":          cty.NullVal(cty.Number),
+			"max_agent_count":     cty.NullVal(cty.Number),
+		}
+		for k, v := range attrs {
+			block[k] = v
+		}
+		return cty.ObjectVal(map[string]cty.Value{
+			"rollout_options": cty.ListVal([]cty.Value{cty.ObjectVal(block)}),
+		})
+	}
+
+	cases := []struct {
+		name         string
+		raw          cty.Value
+		expect       map[string]bool
+		expectExists bool
+	}{
+		{
+			"no-raw-config",
+			cty.NullVal(cty.DynamicPseudoType),
+			nil,
+			false,
+		},
+		{
+			"no-rollout-options",
+			cty.ObjectVal(map[string]cty.Value{
+				"rollout_options": cty.ListValEmpty(cty.DynamicPseudoType),
+			}),
+			map[string]bool{},
+			true,
+		},
+		{
+			"unset",
+			options(nil),
+			map[string]bool{},
+			true,
+		},
+		{
+			"zero-max-errors",
+			options(map[string]cty.Value{
+				"max_errors":      cty.NumberIntVal(0),
+				"max_agent_count": cty.NumberIntVal(10),
+			}),
+			map[string]bool{"max_errors": true, "max_agent_count": true},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			configured, ok := rawConfiguredRolloutOptions(tc.raw)
+			require.Equal(t, tc.expectExists, ok)
+			require.Equal(t, tc.expect, configured)
+		})
+	}
+}
+
+func TestExpandRolloutParametersConfigured(t *testing.T) {
+	options := map[string]any{
+		"max_errors":          0,
+		"initial_agent_count": 20,
+		"multiplier":          float64(0),
+		"max_agent_count":     10,
+	}
+
+	// Computed values which are not configured are left to Bindplane
+	parameters, err := expandRolloutParameters("standard", options, map[string]bool{"max_errors": true})
+	require.NoError(t, err)
+	require.Equal(t, []model.Parameter{{Name: "maxErrors", Value: 0}}, parameters)
+
+	// initial_agent_count is only compared to a configured max_agent_count
+	parameters, err = expandRolloutParameters("standard", options, map[string]bool{"max_agent_count": true})
+	require.NoError(t, err)
+	require.Equal(t, []model.Parameter{{Name: "maxAgents", Value: 10}}, parameters)
+
+	_, err = expandRolloutParameters("standard", options, map[string]bool{"initial_agent_count": true, "max_agent_count": true})
+	require.EqualError(t, err, "initial_agent_count (20) cannot be greater than max_agent_count (10)")
+}
+
+func TestValidateStageLabels(t *testing.T) {
+	_, errs := validateStageLabels(map[string]any{"env": "prod"}, "labels")
+	require.Empty(t, errs)
+
+	_, errs = validateStageLabels(map[string]any{}, "labels")
+	require.Len(t, errs, 1)
+}
+
+func TestResourceConfigurationRolloutOptionsRead(t *testing.T) {
+	stages := []any{
+		map[string]any{
+			"name":            "production",
+			"labels":          map[string]any{"env": "production"},
+			"requireApproval": true,
+		},
+	}
+	progressive := model.ResourceConfiguration{
+		ParameterizedSpec: model.ParameterizedSpec{
+			Type: "progressive",
+			Parameters: []model.Parameter{
+				{Name: "stages", Value: stages},
+				{Name: "maxErrors", Value: float64(3)},
+				{Name: "multiplier", Value: float64(2)},
+			},
+		},
+	}
+
+	t.Run("default-standard", func(t *testing.T) {
+		d := rolloutOptionsResourceData(t, nil)
+		standard := model.ResourceConfiguration{ParameterizedSpec: model.ParameterizedSpec{Type: "standard"}}
+		require.NoError(t, resourceConfigurationRolloutOptionsRead(d, standard))
+		require.Empty(t, d.Get("rollout_options"))
+	})
+
+	t.Run("configured-standard", func(t *testing.T) {
+		d := rolloutOptionsResourceData(t, map[string]any{"type": "standard"})
+		standard := model.ResourceConfiguration{ParameterizedSpec: model.ParameterizedSpec{Type: "standard"}}
+		require.NoError(t, resourceConfigurationRolloutOptionsRead(d, standard))
+		require.Equal(t, "standard", d.Get("rollout_options.0.type"))
+		require.Empty(t, d.Get("rollout_options.0.parameters"))
+	})
+
+	t.Run("stages", func(t *testing.T) {
+		d := rolloutOptionsResourceData(t, nil)
+		require.NoError(t, resourceConfigurationRolloutOptionsRead(d, progressive))
+		require.Equal(t, "progressive", d.Get("rollout_options.0.type"))
+		require.Equal(t, 3, d.Get("rollout_options.0.max_errors"))
+		require.Equal(t, float64(2), d.Get("rollout_options.0.multiplier"))
+		require.Equal(t, "production", d.Get("rollout_options.0.stage.0.name"))
+		require.Equal(t, map[string]any{"env": "production"}, d.Get("rollout_options.0.stage.0.labels"))
+		require.Equal(t, true, d.Get("rollout_options.0.stage.0.require_approval"))
+		require.Empty(t, d.Get("rollout_options.0.parameters"))
+	})
+
+	t.Run("legacy-parameters", func(t *testing.T) {
+		d := rolloutOptionsResourceData(t, map[string]any{
+			"type": "progressive",
+			"parameters": []any{
+				map[string]any{
+					"name": "stages",
+					"value": []any{
+						map[string]any{
+							"name":   "production",
+							"labels": map[string]any{"env": "production"},
+						},
+					},
+				},
+			},
+		})
+		require.NoError(t, resourceConfigurationRolloutOptionsRead(d, progressive))
+		require.Equal(t, "stages", d.Get("rollout_options.0.parameters.0.name"))
+		require.Equal(t, "production", d.Get("rollout_options.0.parameters.0.value.0.name"))
+		require.Empty(t, d.Get("rollout_options.0.stage"))
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		d := rolloutOptionsResourceData(t, map[string]any{"type": "progressive"})
+		require.NoError(t, resourceConfigurationRolloutOptionsRead(d, model.ResourceConfiguration{}))
+		require.Empty(t, d.Get("rollout_options"))
+	})
+}
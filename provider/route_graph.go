@@ -0,0 +1,264 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/observiq/terraform-provider-bindplane/internal/resourcetype"
+)
+
+// routeBlocks are the configuration blocks which are part of the route
+// graph, and the route component prefix used to route to them.
+// kind and typeKind are used to look up the telemetry types supported
+// by the block's component type, and are empty for processor groups.
+var routeBlocks = []struct {
+	key      string
+	prefix   string
+	kind     model.Kind
+	typeKind model.Kind
+}{
+	{"source", "", model.KindSource, model.KindSourceType},
+	{"connector", component.RoutePrefixConnector, model.KindConnector, model.KindConnectorType},
+	{"processor_group", component.RoutePrefixProcessor, "", ""},
+	{"destination", component.RoutePrefixDestination, model.KindDestination, model.KindDestinationType},
+}
+
+// customizeDiffRouteGraph validates the routes between a configuration's
+// sources, connectors, processor groups, and destinations at plan time.
+// Validation is skipped when a route ID or route component is not known
+// until apply, such as a route ID set to another resource's ID.
+//
+// Routes are checked against the telemetry types supported by each
+// source, connector, and destination type, which are looked up
+// from Bindplane.
+func customizeDiffRouteGraph(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	for _, b := range routeBlocks {
+		if !d.NewValueKnown(b.key) {
+			return nil
+		}
+	}
+	if !d.NewValueKnown("pipeline") {
+		return nil
+	}
+
+	nodes, known, err := routeNodes(d.Get)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return nil
+	}
+
+	if bindplane, ok := meta.(*client.BindPlane); ok {
+		setTelemetryTypes(nodes, d.Get, bindplane.AnyResource)
+	}
+
+	return errors.Join(component.ValidateRouteGraph(nodes)...)
+}
+
+// routeNodes returns the route graph nodes of a configuration, followed by
+// the nodes generated for its pipelines. get returns the value of a
+// configuration option. Returns false if a route ID, route component, or
+// pipeline option is not known.
+func routeNodes(get func(string) any) ([]component.RouteNode, bool, error) {
+	nodes := []component.RouteNode{}
+	for _, b := range routeBlocks {
+		blocks, _ := get(b.key).([]any)
+		for i, v := range blocks {
+			block, _ := v.(map[string]any)
+			node := component.RouteNode{
+				Block:  fmt.Sprintf("%s.%d", b.key, i),
+				Prefix: b.prefix,
+			}
+
+			if b.prefix != "" {
+				node.RouteID, _ = block["route_id"].(string)
+				if !isKnown(node.RouteID) {
+					return nil, false, nil
+				}
+			}
+
+			if set, ok := block["route"].(*schema.Set); ok {
+				rawRoutes := set.List()
+				if !routesKnown(rawRoutes) {
+					return nil, false, nil
+				}
+				routes, err := component.ParseRoutes(rawRoutes)
+				if err != nil {
+					return nil, false, fmt.Errorf("%s: %w", node.Block, err)
+				}
+				node.Routes = routes
+			}
+
+			nodes = append(nodes, node)
+		}
+	}
+
+	rawPipelines, _ := get("pipeline").([]any)
+	pipelines, known := readPipelines(rawPipelines)
+	if !known {
+		return nil, false, nil
+	}
+	c, err := component.ExpandPipelines(pipelines)
+	if err != nil {
+		return nil, false, err
+	}
+	return append(nodes, pipelineNodes(c)...), true, nil
+}
+
+// pipelineNodes returns the route graph nodes of the
+// components generated for pipelines.
+func pipelineNodes(c component.PipelineComponents) []component.RouteNode {
+	nodes := []component.RouteNode{}
+	for _, s := range c.Sources {
+		nodes = append(nodes, component.RouteNode{
+			Block:  fmt.Sprintf("pipeline source '%s'", s.Name),
+			Routes: s.Routes,
+		})
+	}
+	for _, p := range c.Processors {
+		nodes = append(nodes, component.RouteNode{
+			Block:   fmt.Sprintf("pipeline processor group '%s'", p.ID),
+			Prefix:  component.RoutePrefixProcessor,
+			RouteID: p.ID,
+			Routes:  p.Routes,
+		})
+	}
+	for _, d := range c.Destinations {
+		nodes = append(nodes, component.RouteNode{
+			Block:   fmt.Sprintf("pipeline destination '%s'", d.Name),
+			Prefix:  component.RoutePrefixDestination,
+			RouteID: d.ID,
+		})
+	}
+	return nodes
+}
+
+// routesKnown returns false if any route component is not known. Terraform
+// reads a list containing an unknown value as an empty list, so routes
+// without components are not known.
+func routesKnown(rawRoutes []any) bool {
+	for _, r := range rawRoutes {
+		route, _ := r.(map[string]any)
+		components, _ := route["components"].([]any)
+		if len(components) == 0 {
+			return false
+		}
+		for _, c := range components {
+			if s, _ := c.(string); !isKnown(s) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// setTelemetryTypes sets the telemetry types supported by each node's
+// component type. nodes are the nodes returned by routeNodes. The component
+// type is the inline type, or the type of the named resource. Telemetry
+// types are left unset when the component type or its telemetry types
+// cannot be found, such as when a named resource is created by the same
+// apply.
+func setTelemetryTypes(nodes []component.RouteNode, get func(string) any, lookup resourceLookup) {
+	cache := map[string][]string{}
+	cached := func(kind model.Kind, name string) []string {
+		key := fmt.Sprintf("%s/%s", kind, name)
+		if types, ok := cache[key]; ok {
+			return types
+		}
+		types := telemetryTypes(lookup, kind, name)
+		cache[key] = types
+		return types
+	}
+	named := func(kind, typeKind model.Kind, name string) []string {
+		rType := componentType(lookup, kind, name)
+		if !isKnown(rType) {
+			return nil
+		}
+		return cached(typeKind, rType)
+	}
+
+	i := 0
+	for _, b := range routeBlocks {
+		blocks, _ := get(b.key).([]any)
+		for _, v := range blocks {
+			node := &nodes[i]
+			i++
+			if b.typeKind == "" {
+				continue
+			}
+
+			block, _ := v.(map[string]any)
+			if name, _ := block["name"].(string); isKnown(name) {
+				node.TelemetryTypes = named(b.kind, b.typeKind, name)
+				continue
+			}
+			if rType, _ := block["type"].(string); isKnown(rType) {
+				node.TelemetryTypes = cached(b.typeKind, rType)
+			}
+		}
+	}
+
+	// Pipeline nodes follow the block nodes, in the
+	// order returned by pipelineNodes.
+	rawPipelines, _ := get("pipeline").([]any)
+	pipelines, _ := readPipelines(rawPipelines)
+	c, err := component.ExpandPipelines(pipelines)
+	if err != nil || len(nodes) != i+len(c.Sources)+len(c.Processors)+len(c.Destinations) {
+		return
+	}
+	for _, s := range c.Sources {
+		nodes[i].TelemetryTypes = named(model.KindSource, model.KindSourceType, s.Name)
+		i++
+	}
+	i += len(c.Processors)
+	for _, d := range c.Destinations {
+		nodes[i].TelemetryTypes = named(model.KindDestination, model.KindDestinationType, d.Name)
+		i++
+	}
+}
+
+// componentType returns the type of a named resource without
+// its version, or an empty string if it cannot be found.
+func componentType(lookup resourceLookup, kind model.Kind, name string) string {
+	r, err := lookup(kind, name)
+	if err != nil || r == nil {
+		return ""
+	}
+	rType, _ := r.Spec["type"].(string)
+	return strings.Split(rType, ":")[0]
+}
+
+// telemetryTypes returns the telemetry types supported by a resource
+// type, or nil if they cannot be found.
+func telemetryTypes(lookup resourceLookup, typeKind model.Kind, rType string) []string {
+	r, err := lookup(typeKind, rType)
+	if err != nil || r == nil {
+		return nil
+	}
+	t, err := resourcetype.FromAnyResource(r)
+	if err != nil || len(t.TelemetryTypes) == 0 {
+		return nil
+	}
+	return t.TelemetryTypes
+}
@@ -0,0 +1,174 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/internal/component"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteNodes(t *testing.T) {
+	route := func(components ...any) []any {
+		return []any{
+			map[string]any{
+				"route_id":       "route",
+				"telemetry_type": "logs",
+				"components":     components,
+			},
+		}
+	}
+
+	raw := map[string]any{
+		"name":     "my-config",
+		"platform": "linux",
+		"rollout":  true,
+		"source": []any{
+			map[string]any{
+				"name":  "host",
+				"route": route("processors/parse", "destinations/typo"),
+			},
+		},
+		"processor_group": []any{
+			map[string]any{
+				"route_id":   "parse",
+				"processors": []any{"batch"},
+				"route":      route("destinations/google"),
+			},
+		},
+		"destination": []any{
+			map[string]any{
+				"route_id": "google",
+				"name":     "google",
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	nodes, known, err := routeNodes(d.Get)
+	require.NoError(t, err)
+	require.True(t, known)
+	require.Len(t, nodes, 3)
+	require.Equal(t, "source.0", nodes[0].Block)
+	require.Equal(t, "processor_group.0", nodes[1].Block)
+	require.Equal(t, component.RoutePrefixProcessor, nodes[1].Prefix)
+	require.Equal(t, "parse", nodes[1].RouteID)
+	require.Equal(t, "destination.0", nodes[2].Block)
+
+	err = errors.Join(component.ValidateRouteGraph(nodes)...)
+	require.EqualError(t, err, "source.0: route 'route' references destinations/typo which does not exist in the configuration")
+
+	// Route components which are not known until apply skip validation
+	raw["source"] = []any{
+		map[string]any{
+			"name":  "host",
+			"route": route(unknownValue, "destinations/google"),
+		},
+	}
+	d = schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	_, known, err = routeNodes(d.Get)
+	require.NoError(t, err)
+	require.False(t, known)
+
+	// Route IDs which are not known until apply skip validation
+	raw["source"] = []any{map[string]any{"name": "host"}}
+	raw["destination"] = []any{
+		map[string]any{
+			"route_id": unknownValue,
+			"name":     "google",
+		},
+	}
+	d = schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	_, known, err = routeNodes(d.Get)
+	require.NoError(t, err)
+	require.False(t, known)
+}
+
+func TestSetTelemetryTypes(t *testing.T) {
+	resources := map[string]*model.AnyResource{
+		"Source/host":                 {Spec: map[string]any{"type": "hostmetrics:3"}},
+		"SourceType/hostmetrics":      {Spec: map[string]any{"telemetryTypes": []string{"Metrics"}}},
+		"DestinationType/googlecloud": {Spec: map[string]any{"telemetryTypes": []string{"Logs", "Metrics", "Traces"}}},
+	}
+	lookups := 0
+	lookup := func(kind model.Kind, name string) (*model.AnyResource, error) {
+		lookups++
+		return resources[fmt.Sprintf("%s/%s", kind, name)], nil
+	}
+
+	raw := map[string]any{
+		"name":     "my-config",
+		"platform": "linux",
+		"rollout":  true,
+		"source": []any{
+			map[string]any{"name": "host"},
+			map[string]any{"name": "missing"},
+		},
+		"processor_group": []any{
+			map[string]any{"route_id": "parse", "processors": []any{"batch"}},
+		},
+		"destination": []any{
+			map[string]any{"route_id": "a", "type": "googlecloud"},
+			map[string]any{"route_id": "b", "type": "googlecloud"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	nodes, known, err := routeNodes(d.Get)
+	require.NoError(t, err)
+	require.True(t, known)
+
+	setTelemetryTypes(nodes, d.Get, lookup)
+	require.Equal(t, []string{"Metrics"}, nodes[0].TelemetryTypes)
+	require.Nil(t, nodes[1].TelemetryTypes, "missing sources are not checked")
+	require.Nil(t, nodes[2].TelemetryTypes, "processor groups are not checked")
+	require.Equal(t, []string{"Logs", "Metrics", "Traces"}, nodes[3].TelemetryTypes)
+	require.Equal(t, nodes[3].TelemetryTypes, nodes[4].TelemetryTypes)
+
+	// host, hostmetrics, missing, and googlecloud once
+	require.Equal(t, 4, lookups)
+
+	// Pipeline sources and destinations are looked up by name
+	resources["Destination/google"] = &model.AnyResource{Spec: map[string]any{"type": "googlecloud:2"}}
+	raw = map[string]any{
+		"name":     "my-config",
+		"platform": "linux",
+		"rollout":  true,
+		"pipeline": []any{
+			map[string]any{
+				"telemetry_types": []any{"traces"},
+				"sources":         []any{"host"},
+				"processors":      []any{"batch"},
+				"destinations":    []any{"google"},
+			},
+		},
+	}
+	d = schema.TestResourceDataRaw(t, resourceConfigurationV2().Schema, raw)
+	nodes, known, err = routeNodes(d.Get)
+	require.NoError(t, err)
+	require.True(t, known)
+
+	setTelemetryTypes(nodes, d.Get, lookup)
+	require.Equal(t, []string{"Metrics"}, nodes[0].TelemetryTypes)
+	require.Nil(t, nodes[1].TelemetryTypes)
+	require.Equal(t, []string{"Logs", "Metrics", "Traces"}, nodes[2].TelemetryTypes)
+	require.EqualError(t, errors.Join(component.ValidateRouteGraph(nodes)...),
+		"pipeline source 'host': route 'pipeline-0-traces' routes traces, but the source only supports Metrics")
+}
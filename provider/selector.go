@@ -0,0 +1,159 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/observiq/terraform-provider-bindplane/internal/maputil"
+)
+
+// unknownValue is the value Terraform uses for values
+// which are not known until apply.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+var selectorSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"match_labels": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateMatchLabels,
+				Description:  "Labels an agent must have for the configuration to apply to it.",
+			},
+		},
+	},
+	Description: "Selects the agents the configuration applies to. Defaults to agents with the label configuration=<name>.",
+}
+
+func validateMatchLabels(val any, _ string) (warns []string, errs []error) {
+	m, err := maputil.StringMapFromTFMap(val.(map[string]any))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	if len(m) == 0 {
+		return nil, []error{errors.New("match_labels must contain at least one label")}
+	}
+
+	if _, err := model.LabelsFromMap(m); err != nil {
+		errs = append(errs, fmt.Errorf("invalid match_labels: %w", err))
+	}
+	return
+}
+
+// defaultMatchLabels returns the match labels used
+// when a configuration does not set a selector.
+func defaultMatchLabels(name string) map[string]string {
+	return map[string]string{
+		"configuration": name,
+	}
+}
+
+// configurationMatchLabels returns the match labels of the
+// configuration's selector block, or the default match labels.
+// get returns the value of a configuration option.
+func configurationMatchLabels(get func(string) any, name string) (map[string]string, error) {
+	selector, _ := get("selector").([]any)
+	if len(selector) == 0 || selector[0] == nil {
+		return defaultMatchLabels(name), nil
+	}
+
+	matchLabels, err := maputil.StringMapFromTFMap(selector[0].(map[string]any)["match_labels"].(map[string]any))
+	if err != nil {
+		return nil, fmt.Errorf("selector match_labels: %w", err)
+	}
+	return matchLabels, nil
+}
+
+// setSelector saves the match labels read from Bindplane to the
+// selector block. The block is left unset when the configuration
+// does not use one and the match labels are the default.
+func setSelector(d *schema.ResourceData, name string, matchLabels map[string]string) error {
+	selector := []any{}
+	if len(d.Get("selector").([]any)) > 0 || !reflect.DeepEqual(matchLabels, defaultMatchLabels(name)) {
+		selector = append(selector, map[string]any{
+			"match_labels": matchLabels,
+		})
+	}
+	return d.Set("selector", selector)
+}
+
+// customizeDiffSelector compares the configuration's planned selector
+// with the selectors of the other configurations in Bindplane, and adds
+// a plan warning if they would compete for the same agents. The check is
+// skipped when the selector is not known until apply, or when the
+// configurations cannot be listed.
+func customizeDiffSelector(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	bindplane, ok := meta.(*client.BindPlane)
+	if !ok || !d.NewValueKnown("name") || !d.NewValueKnown("selector") {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	matchLabels, err := configurationMatchLabels(d.Get, name)
+	if err != nil {
+		return err
+	}
+	for _, v := range matchLabels {
+		if v == unknownValue {
+			return nil
+		}
+	}
+
+	selectors, err := bindplane.ConfigurationSelectors()
+	if err != nil {
+		// The warning is informational, the server may not
+		// be reachable until the provider is applied.
+		return nil
+	}
+
+	overlapping := client.OverlappingSelectors(name, matchLabels, selectors)
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	addPlanWarning(ctx,
+		fmt.Sprintf("Configuration %q selector overlaps with other configurations", name),
+		fmt.Sprintf(
+			"The selector of configuration %q (%s) overlaps with the selectors of the following configurations: %s. "+
+				"An agent can only use one configuration, so these configurations will compete for the agents "+
+				"matched by both selectors. Use distinct selector match_labels for each configuration.",
+			name, formatLabels(matchLabels), strings.Join(overlapping, ", ")),
+	)
+	return nil
+}
+
+// formatLabels returns labels as sorted, comma separated key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,81 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMatchLabels(t *testing.T) {
+	_, errs := validateMatchLabels(map[string]any{"env": "prod"}, "match_labels")
+	require.Empty(t, errs)
+
+	_, errs = validateMatchLabels(map[string]any{}, "match_labels")
+	require.Len(t, errs, 1)
+
+	_, errs = validateMatchLabels(map[string]any{"": "prod"}, "match_labels")
+	require.Len(t, errs, 1)
+}
+
+func TestConfigurationSelector(t *testing.T) {
+	schemaMap := map[string]*schema.Schema{
+		"selector": selectorSchema,
+	}
+
+	t.Run("default", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, schemaMap, map[string]any{})
+
+		matchLabels, err := configurationMatchLabels(d.Get, "my-config")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"configuration": "my-config"}, matchLabels)
+
+		require.NoError(t, setSelector(d, "my-config", matchLabels))
+		require.Empty(t, d.Get("selector"))
+
+		// Match labels changed outside of Terraform are saved to the
+		// selector block so that the difference is planned.
+		require.NoError(t, setSelector(d, "my-config", map[string]string{"env": "prod"}))
+		require.Len(t, d.Get("selector"), 1)
+	})
+
+	t.Run("selector", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, schemaMap, map[string]any{
+			"selector": []any{
+				map[string]any{
+					"match_labels": map[string]any{"env": "prod", "role": "web"},
+				},
+			},
+		})
+
+		matchLabels, err := configurationMatchLabels(d.Get, "my-config")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"env": "prod", "role": "web"}, matchLabels)
+
+		require.NoError(t, setSelector(d, "my-config", matchLabels))
+		require.Equal(t, []any{
+			map[string]any{
+				"match_labels": map[string]any{"env": "prod", "role": "web"},
+			},
+		}, d.Get("selector"))
+	})
+}
+
+func TestFormatLabels(t *testing.T) {
+	require.Equal(t, "env=prod,role=web", formatLabels(map[string]string{"role": "web", "env": "prod"}))
+	require.Equal(t, "", formatLabels(nil))
+}
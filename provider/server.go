@@ -0,0 +1,241 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+// ProviderServer returns the provider's Terraform protocol server. The
+// plugin SDK provider, which defines the resources and data sources, is
+// served together with functionProvider, which defines the functions.
+func ProviderServer(ctx context.Context) (tfprotov5.ProviderServer, error) {
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProviderServer,
+		providerserver.NewProtocol5(&functionProvider{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return server.ProviderServer(), nil
+}
+
+// sdkProviderServer returns the plugin SDK provider's server. It wraps
+// the SDK's server to support behavior the SDK does not, such as warnings
+// during plan and moving state between resource types.
+func sdkProviderServer() tfprotov5.ProviderServer {
+	provider := Provider()
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(provider),
+		provider:       provider,
+	}
+}
+
+// providerServer wraps the plugin SDK's provider server
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	// provider is the SDK provider served by ProviderServer. It can be
+	// nil, in which case the configured Bindplane client is not used.
+	provider *schema.Provider
+}
+
+// PlanResourceChange plans a resource with the SDK server and returns
+// the warnings added with addPlanWarning while planning.
+func (s *providerServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	ctx, warnings := withPlanWarnings(ctx)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, warnings.diagnostics()...)
+	}
+	return resp, err
+}
+
+// MoveResourceState moves the state of a bindplane_configuration to a
+// bindplane_configuration_v2 when the resource is renamed with a moved
+// block. The plugin SDK cannot move state between resource types, so the
+// v1 state is converted to the v2 state here, the same as when a v1
+// configuration is read by bindplane_configuration_v2, which keeps the
+// sensitive parameter values saved to state. If the state cannot be
+// converted, the configuration is imported by name instead, the same as
+// terraform import. Either way, the read which follows the move refreshes
+// the state from Bindplane. Other moves are handled by the SDK server.
+func (s *providerServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	if req == nil || req.SourceTypeName != "bindplane_configuration" || req.TargetTypeName != "bindplane_configuration_v2" {
+		return s.ProviderServer.MoveResourceState(ctx, req)
+	}
+
+	resp := &tfprotov5.MoveResourceStateResponse{}
+	moveError := func(detail string) (*tfprotov5.MoveResourceStateResponse, error) {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Unable to move bindplane_configuration to bindplane_configuration_v2",
+			Detail:   detail,
+		})
+		return resp, nil
+	}
+
+	name, err := movedConfigurationName(req.SourceState)
+	if err != nil {
+		return moveError(err.Error())
+	}
+
+	if state, err := s.moveConfigurationState(ctx, req); err == nil {
+		resp.TargetState = state
+		return resp, nil
+	}
+
+	imported, err := s.ProviderServer.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		ID:       name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Diagnostics = imported.Diagnostics
+	for _, r := range imported.ImportedResources {
+		if r.TypeName == req.TargetTypeName {
+			resp.TargetState = r.State
+			resp.TargetPrivate = r.Private
+			return resp, nil
+		}
+	}
+	if diagnosticsHaveError(resp.Diagnostics) {
+		return resp, nil
+	}
+	return moveError(fmt.Sprintf("configuration '%s' was not imported", name))
+}
+
+// moveConfigurationState converts the raw state of a bindplane_configuration
+// to the state of a bindplane_configuration_v2. The converted raw state is
+// decoded by the SDK server, which checks it against the v2 schema.
+func (s *providerServer) moveConfigurationState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.DynamicValue, error) {
+	state := map[string]any{}
+	if err := json.Unmarshal(req.SourceState.JSON, &state); err != nil {
+		return nil, fmt.Errorf("read bindplane_configuration state: %w", err)
+	}
+
+	telemetryTypes := func(model.ResourceConfiguration, bool) []string { return nil }
+	if s.provider != nil {
+		if bindplane, ok := s.provider.Meta().(*client.BindPlane); ok {
+			telemetryTypes = componentTelemetryTypes(bindplane)
+		}
+	}
+
+	state, err := configurationV1StateToV2(state, telemetryTypes)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("marshal bindplane_configuration_v2 state: %w", err)
+	}
+
+	upgraded, err := s.ProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		Version:  int64(resourceConfigurationV2().SchemaVersion),
+		RawState: &tfprotov5.RawState{JSON: raw},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if diagnosticsHaveError(upgraded.Diagnostics) || upgraded.UpgradedState == nil {
+		return nil, errors.New("the bindplane_configuration_v2 state is not valid")
+	}
+	return upgraded.UpgradedState, nil
+}
+
+// movedConfigurationName returns the configuration name
+// saved in the state of a bindplane_configuration.
+func movedConfigurationName(state *tfprotov5.RawState) (string, error) {
+	if state == nil || len(state.JSON) == 0 {
+		return "", errors.New("the bindplane_configuration state is empty")
+	}
+
+	attributes := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal(state.JSON, &attributes); err != nil {
+		return "", fmt.Errorf("read bindplane_configuration state: %w", err)
+	}
+	if attributes.Name == "" {
+		return "", errors.New("the bindplane_configuration state does not have a name")
+	}
+	return attributes.Name, nil
+}
+
+// diagnosticsHaveError returns true if any of the diagnostics is an error
+func diagnosticsHaveError(diagnostics []*tfprotov5.Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d != nil && d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// planWarningsKey is the context key of a plan's warnings
+type planWarningsKey struct{}
+
+// planWarnings are the warnings added while planning a resource.
+// The plugin SDK's CustomizeDiff functions can only return errors,
+// so warnings are collected from the plan's context instead.
+type planWarnings struct {
+	mu       sync.Mutex
+	warnings []*tfprotov5.Diagnostic
+}
+
+// withPlanWarnings returns a context which collects
+// the warnings added with addPlanWarning.
+func withPlanWarnings(ctx context.Context) (context.Context, *planWarnings) {
+	w := &planWarnings{}
+	return context.WithValue(ctx, planWarningsKey{}, w), w
+}
+
+// addPlanWarning adds a warning to the plan of the resource being
+// planned with ctx. The warning is dropped if ctx is not a plan's
+// context, such as when CustomizeDiff is called directly in tests.
+func addPlanWarning(ctx context.Context, summary, detail string) {
+	w, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// diagnostics returns the warnings as Terraform diagnostics
+func (w *planWarnings) diagnostics() []*tfprotov5.Diagnostic {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.warnings
+}
@@ -0,0 +1,263 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	bpclient "github.com/observiq/bindplane-op-enterprise/client"
+	"github.com/observiq/bindplane-op-enterprise/model"
+	"github.com/observiq/terraform-provider-bindplane/client"
+	"github.com/stretchr/testify/require"
+)
+
+// planServer is a provider server which adds
+// a plan warning when planning a resource.
+type planServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (planServer) PlanResourceChange(ctx context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	addPlanWarning(ctx, "summary", "detail")
+	return &tfprotov5.PlanResourceChangeResponse{}, nil
+}
+
+func TestProviderServerPlanWarnings(t *testing.T) {
+	s := &providerServer{ProviderServer: planServer{}}
+
+	resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "summary",
+			Detail:   "detail",
+		},
+	}, resp.Diagnostics)
+
+	// Warnings are not shared between plans
+	resp, err = s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+
+	// Warnings added outside of a plan are dropped
+	addPlanWarning(context.Background(), "summary", "detail")
+}
+
+// importServer is a provider server which imports configurations which
+// exist, and reports moves and state upgrades as not supported.
+type importServer struct {
+	tfprotov5.ProviderServer
+	imported []string
+}
+
+func (s *importServer) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	s.imported = append(s.imported, req.ID)
+	if req.ID == "missing" {
+		return &tfprotov5.ImportResourceStateResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{Severity: tfprotov5.DiagnosticSeverityError, Summary: "Configuration with name 'missing' does not exist"},
+			},
+		}, nil
+	}
+	return &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &tfprotov5.DynamicValue{JSON: []byte(req.ID)},
+				Private:  []byte("private"),
+			},
+		},
+	}, nil
+}
+
+func (s *importServer) MoveResourceState(context.Context, *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	return &tfprotov5.MoveResourceStateResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{Severity: tfprotov5.DiagnosticSeverityError, Summary: "Move Resource State Not Supported"},
+		},
+	}, nil
+}
+
+func (s *importServer) UpgradeResourceState(context.Context, *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
+	return &tfprotov5.UpgradeResourceStateResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{Severity: tfprotov5.DiagnosticSeverityError, Summary: "Upgrade Resource State Not Supported"},
+		},
+	}, nil
+}
+
+func TestProviderServerMoveResourceState(t *testing.T) {
+	ctx := context.Background()
+	moveRequest := func(sourceType, state string) *tfprotov5.MoveResourceStateRequest {
+		return &tfprotov5.MoveResourceStateRequest{
+			SourceTypeName: sourceType,
+			SourceState:    &tfprotov5.RawState{JSON: []byte(state)},
+			TargetTypeName: "bindplane_configuration_v2",
+		}
+	}
+
+	// The v1 state is converted to the v2 state
+	provider := Provider()
+	s := &providerServer{ProviderServer: schema.NewGRPCProviderServer(provider), provider: provider}
+	resp, err := s.MoveResourceState(ctx, moveRequest("bindplane_configuration", `{
+		"id": "01JKEX6ZZNHHNX171N8JKQC57M",
+		"name": "my-config",
+		"platform": "linux",
+		"rollout": true,
+		"source": [{"name": "my-host", "processors": ["my-batch"]}],
+		"destination": [{"type": "googlecloud", "parameters_json": "[{\"name\":\"project\",\"value\":\"my-project\"}]"}]
+	}`))
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+	require.NotNil(t, resp.TargetState)
+
+	schemas, err := s.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	state, err := resp.TargetState.Unmarshal(schemas.ResourceSchemas["bindplane_configuration_v2"].ValueType())
+	require.NoError(t, err)
+	get := func(value tftypes.Value, path *tftypes.AttributePath) string {
+		v, _, err := tftypes.WalkAttributePath(value, path)
+		require.NoError(t, err, path.String())
+		var s string
+		require.NoError(t, v.(tftypes.Value).As(&s), path.String())
+		return s
+	}
+	require.Equal(t, "01JKEX6ZZNHHNX171N8JKQC57M", get(state, tftypes.NewAttributePath().WithAttributeName("id")))
+	require.Equal(t, configurationAPIVersionV1, get(state, tftypes.NewAttributePath().WithAttributeName("api_version")))
+	require.Equal(t, "my-batch", get(state, tftypes.NewAttributePath().WithAttributeName("source").WithElementKeyInt(0).WithAttributeName("processors").WithElementKeyInt(0)))
+	require.Equal(t, "googlecloud", get(state, tftypes.NewAttributePath().WithAttributeName("destination").WithElementKeyInt(0).WithAttributeName("route_id")))
+	require.Equal(t, `[{"name":"project","value":"my-project"}]`, get(state, tftypes.NewAttributePath().WithAttributeName("destination").WithElementKeyInt(0).WithAttributeName("parameters_json")))
+
+	// Each telemetry type is routed from the source to the destination
+	routes, _, err := tftypes.WalkAttributePath(state, tftypes.NewAttributePath().WithAttributeName("source").WithElementKeyInt(0).WithAttributeName("route"))
+	require.NoError(t, err)
+	var routeValues []tftypes.Value
+	require.NoError(t, routes.(tftypes.Value).As(&routeValues))
+	routed := map[string]string{}
+	for _, r := range routeValues {
+		telemetryType := get(r, tftypes.NewAttributePath().WithAttributeName("telemetry_type"))
+		routed[telemetryType] = get(r, tftypes.NewAttributePath().WithAttributeName("components").WithElementKeyInt(0))
+	}
+	require.Equal(t, map[string]string{
+		"logs":    "destinations/googlecloud",
+		"metrics": "destinations/googlecloud",
+		"traces":  "destinations/googlecloud",
+	}, routed)
+
+	// States which cannot be converted are imported by name
+	inner := &importServer{}
+	s = &providerServer{ProviderServer: inner}
+	move := func(sourceType, state string) *tfprotov5.MoveResourceStateResponse {
+		resp, err := s.MoveResourceState(ctx, moveRequest(sourceType, state))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = move("bindplane_configuration", `{"id":"01JKEX6ZZNHHNX171N8JKQC57M","name":"my-config"}`)
+	require.Empty(t, resp.Diagnostics)
+	require.Equal(t, &tfprotov5.DynamicValue{JSON: []byte("my-config")}, resp.TargetState)
+	require.Equal(t, []byte("private"), resp.TargetPrivate)
+	require.Equal(t, []string{"my-config"}, inner.imported)
+
+	resp = move("bindplane_configuration", `{"name":"my-config","source":"not a block"}`)
+	require.Empty(t, resp.Diagnostics)
+	require.Equal(t, &tfprotov5.DynamicValue{JSON: []byte("my-config")}, resp.TargetState)
+
+	// Import errors are returned
+	resp = move("bindplane_configuration", `{"name":"missing"}`)
+	require.Nil(t, resp.TargetState)
+	require.Len(t, resp.Diagnostics, 1)
+	require.Equal(t, "Configuration with name 'missing' does not exist", resp.Diagnostics[0].Summary)
+
+	// States without a name cannot be moved
+	resp = move("bindplane_configuration", `{"id":"01JKEX6ZZNHHNX171N8JKQC57M"}`)
+	require.Nil(t, resp.TargetState)
+	require.Len(t, resp.Diagnostics, 1)
+	require.Equal(t, "the bindplane_configuration state does not have a name", resp.Diagnostics[0].Detail)
+
+	// Other moves are handled by the SDK server
+	resp = move("bindplane_raw_configuration", `{"name":"my-config"}`)
+	require.Equal(t, "Move Resource State Not Supported", resp.Diagnostics[0].Summary)
+	require.Equal(t, []string{"my-config", "my-config", "missing"}, inner.imported)
+}
+
+// configurationsClient is a Bindplane client which lists configurations
+type configurationsClient struct {
+	bpclient.Bindplane
+	configs []*model.Configuration
+}
+
+func (c configurationsClient) Configurations(context.Context) ([]*model.Configuration, error) {
+	return c.configs, nil
+}
+
+func TestCustomizeDiffSelector(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":     {Type: schema.TypeString, Required: true},
+			"selector": selectorSchema,
+		},
+		CustomizeDiff: customizeDiffSelector,
+	}
+	configuration := func(name string, matchLabels map[string]string) *model.Configuration {
+		c := &model.Configuration{}
+		c.Metadata.Name = name
+		c.Spec.Selector.MatchLabels = matchLabels
+		return c
+	}
+	bindplane := &client.BindPlane{
+		Client: configurationsClient{
+			configs: []*model.Configuration{
+				configuration("prod", map[string]string{"env": "prod"}),
+				configuration("dev", map[string]string{"env": "dev"}),
+				configuration("web", map[string]string{"configuration": "web"}),
+			},
+		},
+	}
+
+	plan := func(raw map[string]any) []*tfprotov5.Diagnostic {
+		ctx, warnings := withPlanWarnings(context.Background())
+		_, err := r.SimpleDiff(ctx, &terraform.InstanceState{}, terraform.NewResourceConfigRaw(raw), bindplane)
+		require.NoError(t, err)
+		return warnings.diagnostics()
+	}
+	selector := func(labels map[string]any) []any {
+		return []any{map[string]any{"match_labels": labels}}
+	}
+
+	// Configurations are not compared with themselves,
+	// or with configurations which select other agents.
+	require.Empty(t, plan(map[string]any{"name": "prod", "selector": selector(map[string]any{"env": "prod"})}))
+	require.Empty(t, plan(map[string]any{"name": "web"}))
+	require.Empty(t, plan(map[string]any{"name": "staging", "selector": selector(map[string]any{"env": "staging"})}))
+
+	// Every agent matched by the selector is matched by the prod
+	// configuration, regardless of which is planned first.
+	warnings := plan(map[string]any{"name": "prod-web", "selector": selector(map[string]any{"env": "prod", "role": "web"})})
+	require.Len(t, warnings, 1)
+	require.Equal(t, tfprotov5.DiagnosticSeverityWarning, warnings[0].Severity)
+	require.Equal(t, `Configuration "prod-web" selector overlaps with other configurations`, warnings[0].Summary)
+	require.Contains(t, warnings[0].Detail, "(env=prod,role=web)")
+	require.Contains(t, warnings[0].Detail, "following configurations: prod.")
+
+	// Selectors which are not known until apply are not checked
+	require.Empty(t, plan(map[string]any{"name": "unknown", "selector": selector(map[string]any{"env": unknownValue})}))
+}
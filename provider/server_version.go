@@ -0,0 +1,187 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	hashiversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/observiq/terraform-provider-bindplane/client"
+)
+
+// featureGate declares the minimum Bindplane version required
+// by a resource, or by one of its attributes.
+type featureGate struct {
+	// Feature is the name of the feature, reported by the
+	// bindplane_server data source.
+	Feature string
+
+	// Resource is the Terraform resource type
+	Resource string
+
+	// Attribute is the attribute path which requires the minimum
+	// version. When empty, the entire resource requires it.
+	Attribute string
+
+	// MinimumVersion is the minimum supported Bindplane version
+	MinimumVersion string
+}
+
+// featureGates are the resources and attributes which require a
+// minimum Bindplane version. Each minimum version is the version the
+// resource or attribute documentation names as the first to support it.
+//
+// Resources and attributes not listed here are supported by all Bindplane
+// versions supported by the provider, v1.76.0 and newer, the oldest version
+// the end to end tests run against. Processor groups are part of
+// configuration_v2, and are gated with it. Feature gates are checked when
+// planning resources. Data sources are not gated, reads which the server
+// does not support fail with the server's error.
+//
+// Resources added after the gates do not need one when they only use APIs
+// which the resources above already use:
+//   - bindplane_resource and the bindplane_*_type resources use the
+//     generic apply, get, and delete resource API, the same API used by
+//     bindplane_source and the other component resources.
+//   - bindplane_configuration_rollback uses the configuration history and
+//     rollout APIs, the same APIs used by the configuration resources to
+//     detect modifications and start rollouts.
+var featureGates = []featureGate{
+	{
+		// docs/resources/bindplane_connector.md
+		Feature:        "connectors",
+		Resource:       "bindplane_connector",
+		MinimumVersion: "1.85.0",
+	},
+	{
+		// docs/resources/bindplane_configuration_v2.md, and the end to end
+		// test matrix in .github/workflows/ci.yml: "v2 config released as beta"
+		Feature:        "configuration_v2",
+		Resource:       "bindplane_configuration_v2",
+		MinimumVersion: "1.85.0",
+	},
+	{
+		// The port option in docs/resources/bindplane_configuration.md
+		Feature:        "advanced_metrics_port",
+		Resource:       "bindplane_configuration",
+		Attribute:      "advanced.0.metrics.0.port",
+		MinimumVersion: "1.90.2",
+	},
+	{
+		// The port option in docs/resources/bindplane_configuration_v2.md
+		Feature:        "advanced_metrics_port",
+		Resource:       "bindplane_configuration_v2",
+		Attribute:      "advanced.0.metrics.0.port",
+		MinimumVersion: "1.90.2",
+	},
+	{
+		// The level option in docs/resources/bindplane_configuration.md
+		Feature:        "advanced_metrics_level",
+		Resource:       "bindplane_configuration",
+		Attribute:      "advanced.0.metrics.0.level",
+		MinimumVersion: "1.92.0",
+	},
+	{
+		// The level option in docs/resources/bindplane_configuration_v2.md
+		Feature:        "advanced_metrics_level",
+		Resource:       "bindplane_configuration_v2",
+		Attribute:      "advanced.0.metrics.0.level",
+		MinimumVersion: "1.92.0",
+	},
+}
+
+// resourceCustomizeDiff returns the CustomizeDiff function
+// shared by all resources.
+func resourceCustomizeDiff(rType string) schema.CustomizeDiffFunc {
+	return customdiff.All(
+		customizeDiffVersion,
+		customizeDiffServerVersion(rType),
+	)
+}
+
+// customizeDiffServerVersion returns a plan time error when the
+// resource, or one of its configured attributes, is not supported
+// by the Bindplane server.
+func customizeDiffServerVersion(rType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta any) error {
+		bindplane, ok := meta.(*client.BindPlane)
+		if !ok || bindplane == nil {
+			return nil
+		}
+
+		// If the server version cannot be determined, the server
+		// is left to reject unsupported resources.
+		server, err := bindplane.ServerVersion()
+		if err != nil {
+			return nil
+		}
+
+		return checkFeatureGates(rType, server, func(attribute string) bool {
+			_, ok := d.GetOk(attribute)
+			return ok
+		})
+	}
+}
+
+// checkFeatureGates returns an error for each feature gate of the
+// resource type which is not satisfied by the server version. isSet
+// reports whether an attribute is configured.
+func checkFeatureGates(rType string, server *hashiversion.Version, isSet func(attribute string) bool) error {
+	var errs error
+	for _, gate := range featureGates {
+		if gate.Resource != rType {
+			continue
+		}
+
+		if gate.Attribute != "" && !isSet(gate.Attribute) {
+			continue
+		}
+
+		if gateSupported(gate, server) {
+			continue
+		}
+
+		subject := rType
+		if gate.Attribute != "" {
+			subject = fmt.Sprintf("%s attribute %s", rType, gate.Attribute)
+		}
+		errs = errors.Join(errs, fmt.Errorf(
+			"%s requires Bindplane version %s or newer, the Bindplane server is running version %s",
+			subject, gate.MinimumVersion, server))
+	}
+	return errs
+}
+
+// gateSupported returns true if the server version satisfies the
+// feature gate. Pre-release versions satisfy the gate of their
+// release, so that release candidates can be tested.
+func gateSupported(gate featureGate, server *hashiversion.Version) bool {
+	minimum := hashiversion.Must(hashiversion.NewVersion(gate.MinimumVersion))
+	return server.Core().GreaterThanOrEqual(minimum)
+}
+
+// supportedFeatures returns each feature and whether or
+// not the server version supports it.
+func supportedFeatures(server *hashiversion.Version) map[string]bool {
+	features := map[string]bool{}
+	for _, gate := range featureGates {
+		features[gate.Feature] = gateSupported(gate, server)
+	}
+	return features
+}
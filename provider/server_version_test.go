@@ -0,0 +1,109 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	hashiversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureGates(t *testing.T) {
+	for _, gate := range featureGates {
+		_, ok := Provider().ResourcesMap[gate.Resource]
+		require.True(t, ok, "feature gate %s references unknown resource %s", gate.Feature, gate.Resource)
+		_, err := hashiversion.NewVersion(gate.MinimumVersion)
+		require.NoError(t, err)
+	}
+}
+
+func TestCheckFeatureGates(t *testing.T) {
+	none := func(string) bool { return false }
+	all := func(string) bool { return true }
+
+	cases := []struct {
+		name      string
+		rType     string
+		server    string
+		isSet     func(string) bool
+		expectErr string
+	}{
+		{
+			"ungated resource",
+			"bindplane_destination",
+			"1.0.0",
+			all,
+			"",
+		},
+		{
+			"resource supported",
+			"bindplane_connector",
+			"1.85.0",
+			none,
+			"",
+		},
+		{
+			"resource pre-release supported",
+			"bindplane_connector",
+			"1.85.0-rc.1",
+			none,
+			"",
+		},
+		{
+			"resource unsupported",
+			"bindplane_connector",
+			"1.84.3",
+			none,
+			"bindplane_connector requires Bindplane version 1.85.0 or newer, the Bindplane server is running version 1.84.3",
+		},
+		{
+			"attribute unset",
+			"bindplane_configuration",
+			"1.80.0",
+			none,
+			"",
+		},
+		{
+			"attribute unsupported",
+			"bindplane_configuration",
+			"1.91.0",
+			all,
+			"bindplane_configuration attribute advanced.0.metrics.0.level requires Bindplane version 1.92.0 or newer, the Bindplane server is running version 1.91.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := hashiversion.Must(hashiversion.NewVersion(tc.server))
+			err := checkFeatureGates(tc.rType, server, tc.isSet)
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestSupportedFeatures(t *testing.T) {
+	features := supportedFeatures(hashiversion.Must(hashiversion.NewVersion("1.91.0")))
+	require.Equal(t, map[string]bool{
+		"connectors":             true,
+		"configuration_v2":       true,
+		"advanced_metrics_port":  true,
+		"advanced_metrics_level": false,
+	}, features)
+}
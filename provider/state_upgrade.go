@@ -0,0 +1,65 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// stateUpgrader upgrades a resource's state from one
+// schema version to the next.
+type stateUpgrader struct {
+	// Description describes the schema change
+	Description string
+
+	// Upgrade takes the raw state of the previous schema version
+	// and returns the state of the next schema version.
+	Upgrade schema.StateUpgradeFunc
+}
+
+// configurationV2StateUpgraders are the state upgraders
+// of bindplane_configuration_v2.
+var configurationV2StateUpgraders = []stateUpgrader{
+	{
+		Description: "Repair duplicate processor group and destination route IDs",
+		Upgrade:     resourceConfigurationV2StateUpgradeV0,
+	},
+}
+
+// withStateUpgraders sets the schema version and state upgraders of a
+// resource, and returns the resource. The upgrader at index i upgrades
+// states saved with schema version i, and the resource's schema version
+// is the number of upgraders.
+//
+// When a schema change requires existing states to change, such as renaming
+// an attribute or changing how a value is saved, append an upgrader to the
+// resource's list along with a test, instead of requiring users to edit
+// their state. Schema changes which only add attributes do not need an upgrader.
+//
+// Terraform only uses an upgrader's Type to read states saved before
+// Terraform 0.12, which this provider does not support, so the current
+// schema's type is used for every version.
+func withStateUpgraders(r *schema.Resource, upgraders []stateUpgrader) *schema.Resource {
+	r.SchemaVersion = len(upgraders)
+	r.StateUpgraders = make([]schema.StateUpgrader, 0, len(upgraders))
+	for version, u := range upgraders {
+		r.StateUpgraders = append(r.StateUpgraders, schema.StateUpgrader{
+			Version: version,
+			Type:    r.CoreConfigSchema().ImpliedType(),
+			Upgrade: u.Upgrade,
+		})
+	}
+	return r
+}
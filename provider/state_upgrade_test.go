@@ -0,0 +1,115 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateUpgraders(t *testing.T) {
+	for version, u := range configurationV2StateUpgraders {
+		require.NotEmpty(t, u.Description, "upgrader %d requires a description", version)
+		require.NotNil(t, u.Upgrade, "upgrader %d requires an upgrade function", version)
+	}
+
+	// The schema version is set by the resource, not the provider
+	r := resourceConfigurationV2()
+	require.Equal(t, len(configurationV2StateUpgraders), r.SchemaVersion)
+
+	for rType, r := range Provider().ResourcesMap {
+		require.Len(t, r.StateUpgraders, r.SchemaVersion, rType)
+		for version, u := range r.StateUpgraders {
+			require.Equal(t, version, u.Version, rType)
+		}
+	}
+}
+
+func TestUpgradeState(t *testing.T) {
+	r := withStateUpgraders(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}, []stateUpgrader{
+		{
+			Description: "Rename labels to tags",
+			Upgrade: func(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+				rawState["tags"] = rawState["labels"]
+				delete(rawState, "labels")
+				return rawState, nil
+			},
+		},
+		{
+			Description: "Save tags as a list",
+			Upgrade: func(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+				rawState["tags"] = []any{rawState["tags"]}
+				return rawState, nil
+			},
+		},
+	})
+	require.Equal(t, 2, r.SchemaVersion)
+
+	ctx := context.Background()
+
+	state, err := upgradeState(ctx, r, 0, map[string]any{"labels": "a"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"tags": []any{"a"}}, state)
+
+	state, err = upgradeState(ctx, r, 1, map[string]any{"tags": "a"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"tags": []any{"a"}}, state)
+
+	state, err = upgradeState(ctx, r, 2, map[string]any{"tags": []any{"a"}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"tags": []any{"a"}}, state)
+
+	_, err = upgradeState(ctx, r, 3, map[string]any{}, nil)
+	require.EqualError(t, err, "state has schema version 3, newer than the current version 2")
+
+	// Resources without upgraders are at version 0
+	state, err = upgradeState(ctx, resourceSource(), 0, map[string]any{"name": "host"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"name": "host"}, state)
+}
+
+// upgradeState upgrades a resource's raw state saved with schema version
+// to the resource's current schema version, by running each upgrader in
+// order, the same as Terraform does.
+func upgradeState(ctx context.Context, r *schema.Resource, version int, rawState map[string]any, meta any) (map[string]any, error) {
+	if version > r.SchemaVersion {
+		return nil, fmt.Errorf("state has schema version %d, newer than the current version %d", version, r.SchemaVersion)
+	}
+
+	for _, u := range r.StateUpgraders {
+		if u.Version < version {
+			continue
+		}
+
+		var err error
+		rawState, err = u.Upgrade(ctx, rawState, meta)
+		if err != nil {
+			return nil, fmt.Errorf("upgrade state from schema version %d: %w", u.Version, err)
+		}
+	}
+	return rawState, nil
+}